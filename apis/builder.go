@@ -26,3 +26,17 @@ type Builder interface {
 	// ext is an optional extension context. Its meaning is implementation-defined.
 	BuildResolver(cfg Config, reg Registry, res Resolver, ext any) Resolver
 }
+
+// PrevExtBuilder is an optional extension of Builder for builders that need to
+// compare the previous extension context with the new one (e.g. to decide
+// whether a rebuild must discard migrated state). When a Builder implements it,
+// rfx calls these methods instead of BuildRegistry/BuildResolver.
+type PrevExtBuilder interface {
+	Builder
+	// BuildRegistryWithPrevExt is BuildRegistry with the previous ext (prevExt)
+	// alongside the new one (ext). On initial construction prevExt is nil.
+	BuildRegistryWithPrevExt(cfg Config, reg Registry, prevExt, ext any) Registry
+	// BuildResolverWithPrevExt is BuildResolver with the previous ext (prevExt)
+	// alongside the new one (ext). On initial construction prevExt is nil.
+	BuildResolverWithPrevExt(cfg Config, reg Registry, res Resolver, prevExt, ext any) Resolver
+}
@@ -16,6 +16,14 @@
 
 package apis
 
+import (
+	"encoding/binary"
+	"hash"
+	"hash/fnv"
+	"math"
+	"reflect"
+)
+
 // Config carries read-only resolution knobs that influence strategies.
 // It is passed by value and should be treated as immutable by implementations.
 type Config struct {
@@ -25,9 +33,272 @@ type Config struct {
 
 	// MaxUnwrap limits container unwrapping depth (ptr/slice/array/chan/map).
 	// Acts as a safety guard against pathological nesting.
+	// Zero disables unwrapping, so only exact named types resolve;
+	// a negative value selects the default depth.
 	MaxUnwrap int
 
+	// UnwrapFinalNamed grants one extra unwrap once MaxUnwrap is exhausted,
+	// but only when the remaining type is a pointer or slice whose element is
+	// named: **User then resolves to User at MaxUnwrap=1 instead of failing.
+	// Deeper chains still fail, so MaxUnwrap keeps bounding the work.
+	UnwrapFinalNamed bool
+
 	// MapPreferElem controls which side of map[K]V is considered “primary”
 	// when searching for a nearest named inner type. If true, prefer V; otherwise K.
 	MapPreferElem bool
+
+	// NormalizeOutermost makes normalization stop at the first named type
+	// encountered from the outside, even if it is a defined container type:
+	// "type OrderList []Order" then resolves to OrderList instead of Order.
+	// Unnamed containers ([]Order, *Order, map[string]Order) are still unwrapped.
+	// This covers every defined type whose kind is a container, including
+	// named pointers ("type PUser *User") and named channels.
+	NormalizeOutermost bool
+
+	// RejectUnsafeKinds makes normalization treat uintptr and unsafe.Pointer
+	// as unnamed, so such types resolve to "" instead of "uintptr"/"unsafe.Pointer".
+	//
+	// Deprecated: set UnsafeKinds to UnsafeKindReject. If true, it selects
+	// UnsafeKindReject whatever UnsafeKinds says.
+	RejectUnsafeKinds bool
+
+	// UnsafeKinds selects how uintptr and unsafe.Pointer are named: resolved
+	// normally (the default), replaced by a fixed name, rejected with an error
+	// when normalization lands on them, or treated as unnamed. Read it through
+	// UnsafeKindPolicy, which accounts for the deprecated RejectUnsafeKinds.
+	UnsafeKinds UnsafeKindPolicy
+
+	// KeepContainerMarkers makes the reflect strategy keep the unwrapped
+	// container layers in the name, e.g. "[]*pkg.User" instead of "pkg.User".
+	// Intended for debugging; the collapsed form is the stable identity.
+	KeepContainerMarkers bool
+
+	// KeepArrayLen appends the length of an outermost array to the name, so
+	// [2]pkg.A resolves to "pkg.A[2]" and [3]pkg.A to "pkg.A[3]".
+	// It has no effect when KeepContainerMarkers is set, since the markers
+	// already include the length.
+	KeepArrayLen bool
+
+	// DistinguishArrays prefixes "[N]" to names of array-rooted types, so
+	// [16]byte and [32]byte resolve to "[16]uint8" and "[32]uint8" instead of
+	// both collapsing to the element. Arrays nested in other containers
+	// (e.g. *[16]byte) still normalize to the element. It takes precedence
+	// over KeepArrayLen and has no effect with KeepContainerMarkers.
+	DistinguishArrays bool
+
+	// DistinguishPointers prefixes "*" to names of pointer-rooted types, so *T
+	// resolves to "*pkg.T" instead of "pkg.T". Pointers nested in other
+	// containers ([]*T) are not marked. No effect with KeepContainerMarkers.
+	DistinguishPointers bool
+
+	// PointerDepthInName, combined with DistinguishPointers, emits one "*" per
+	// leading pointer level ("**pkg.T" for **T), counting up to MaxUnwrap.
+	PointerDepthInName bool
+
+	// KeepPointerDepth prefixes one "*" per pointer layer peeled during
+	// normalization, wherever it occurs: *T -> "*pkg.T", **T -> "**pkg.T",
+	// *[]T -> "*pkg.T". It takes precedence over DistinguishPointers and has
+	// no effect with KeepContainerMarkers.
+	KeepPointerDepth bool
+
+	// MaxNameLen bounds the length in bytes of names produced by the reflect
+	// strategy. Longer names are truncated and suffixed with "…" and a short
+	// stable hash of the full name, so distinct names stay distinct.
+	// Zero means unlimited.
+	MaxNameLen int
+
+	// RegistryOverridesNamer asks builders to consult the registry before the
+	// Namer interface, inverting the normal priority: a registered name then
+	// wins over a type's own EntityName(), e.g. to rename a third-party type.
+	RegistryOverridesNamer bool
+
+	// GlobalPrefix asks builders to prefix every non-empty resolved name,
+	// whatever strategy produced it, with GlobalPrefix and "/", e.g.
+	// "tenant-a/billing.Invoice". Empty means no prefix.
+	GlobalPrefix string
+
+	// DisableReflectFallback asks builders to leave the reflect strategy out
+	// of the chain, so unregistered types without a Namer resolve to "" and no
+	// package paths leak into names.
+	DisableReflectFallback bool
+
+	// TrimModuleVersion drops a trailing major version segment from package
+	// paths before taking their last element, so types in
+	// "github.com/a/b/v2" resolve to "b.Type" instead of "v2.Type".
+	// It is enabled by DefaultConfig.
+	TrimModuleVersion bool
+
+	// OmitPackage makes the reflect strategy emit the bare type name ("Order")
+	// instead of "pkg.Order". Unlike IncludeBuiltins, which only concerns
+	// types without a package, it affects every named type. Bare names of
+	// different packages can collide; prefer it for display-only tooling.
+	OmitPackage bool
+
+	// FriendlyByteSlices makes the reflect strategy name unnamed []byte
+	// ([]uint8) "bytes" and []rune ([]int32) "runes" instead of unwrapping
+	// them to "uint8" and "int32". Defined slice types are unaffected.
+	FriendlyByteSlices bool
+
+	// PkgPathHashSuffix makes the reflect strategy append "." and four hex
+	// digits of a hash of the full package path, so "a/util.Type" and
+	// "b/util.Type" resolve to distinct names such as "util.Type.9f3c" and
+	// "util.Type.1b2a". Aliased names are left unchanged.
+	PkgPathHashSuffix bool
+
+	// NameStyle selects the final casing of names produced by the reflect
+	// strategy: "pkg.UserAccount" stays as is under NameStyleDotted (the
+	// default) and becomes "pkg_user_account" or "pkg-user-account" under
+	// NameStyleSnakeCase and NameStyleKebabCase. Namer, registry and aliased
+	// names are explicit and never restyled.
+	NameStyle NameStyle
+
+	// TypeAliases remaps names produced by the reflect strategy. Keys are either
+	// the full "pkgpath.Type" (e.g. "time.Time", "github.com/google/uuid.UUID")
+	// or the assembled "pkg.Type" name; values replace the name (e.g. "timestamp").
+	// The full form is consulted first. A nil or empty map disables remapping.
+	// The map must not be mutated after the Config is published.
+	TypeAliases map[string]string
+}
+
+// NameStyle selects how the reflect strategy formats the names it derives.
+type NameStyle int
+
+const (
+	// NameStyleDotted keeps Go's "pkg.Type" spelling.
+	NameStyleDotted NameStyle = iota
+	// NameStyleSnakeCase lowercases, splits words with "_" and replaces
+	// dots with "_": "pkg.HTTPServer" becomes "pkg_http_server".
+	NameStyleSnakeCase
+	// NameStyleKebabCase is NameStyleSnakeCase with "-" instead of "_".
+	NameStyleKebabCase
+)
+
+// UnsafeKindPolicy controls how uintptr and unsafe.Pointer are named.
+type UnsafeKindPolicy int
+
+const (
+	// UnsafeKindResolve names them like any other type: "uintptr" and
+	// "unsafe.Pointer" (the latter also when IncludeBuiltins is false).
+	UnsafeKindResolve UnsafeKindPolicy = iota
+	// UnsafeKindFixedName makes the reflect strategy name them "unsafe".
+	UnsafeKindFixedName
+	// UnsafeKindError makes normalization fail with an error, so they
+	// resolve to "" and cannot be registered.
+	UnsafeKindError
+	// UnsafeKindReject makes normalization treat them as unnamed: they
+	// resolve to "" like UnsafeKindError, but normalization keeps looking
+	// elsewhere, so map[uintptr]User still resolves to User.
+	UnsafeKindReject
+)
+
+// UnsafeKindPolicy returns the policy in effect for uintptr and
+// unsafe.Pointer: UnsafeKindReject if the deprecated RejectUnsafeKinds is
+// set, UnsafeKinds otherwise.
+func (c Config) UnsafeKindPolicy() UnsafeKindPolicy {
+	if c.RejectUnsafeKinds {
+		return UnsafeKindReject
+	}
+	return c.UnsafeKinds
+}
+
+// Equal reports whether c and other configure resolution identically.
+// Fields are compared by reflection, so knobs added later are covered; maps
+// compare by content regardless of order, and a nil map equals an empty one.
+func (c Config) Equal(other Config) bool {
+	a, b := reflect.ValueOf(c), reflect.ValueOf(other)
+	for i := 0; i < a.NumField(); i++ {
+		if !valuesEqual(a.Field(i), b.Field(i)) {
+			return false
+		}
+	}
+	return true
+}
+
+// Hash returns a 64-bit FNV-1a hash of c, consistent with Equal: equal
+// configurations hash equally, and map entries contribute independently of
+// their iteration order. Distinct configurations may collide, so use Hash to
+// bucket configurations and Equal to confirm a match.
+func (c Config) Hash() uint64 {
+	h := fnv.New64a()
+	v := reflect.ValueOf(c)
+	for i := 0; i < v.NumField(); i++ {
+		hashValue(h, v.Field(i))
+	}
+	return h.Sum64()
+}
+
+// valuesEqual compares two values of the same type for Equal.
+func valuesEqual(a, b reflect.Value) bool {
+	switch a.Kind() {
+	case reflect.Map:
+		if a.Len() != b.Len() {
+			return false
+		}
+		iter := a.MapRange()
+		for iter.Next() {
+			bv := b.MapIndex(iter.Key())
+			if !bv.IsValid() || !valuesEqual(iter.Value(), bv) {
+				return false
+			}
+		}
+		return true
+	case reflect.Slice:
+		if a.Len() != b.Len() {
+			return false
+		}
+		for i := 0; i < a.Len(); i++ {
+			if !valuesEqual(a.Index(i), b.Index(i)) {
+				return false
+			}
+		}
+		return true
+	default:
+		return a.Equal(b)
+	}
+}
+
+// hashValue feeds v into h for Hash.
+func hashValue(h hash.Hash64, v reflect.Value) {
+	var buf [8]byte
+	putUint := func(u uint64) {
+		binary.LittleEndian.PutUint64(buf[:], u)
+		h.Write(buf[:])
+	}
+
+	switch v.Kind() {
+	case reflect.Bool:
+		if v.Bool() {
+			putUint(1)
+		} else {
+			putUint(0)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		putUint(uint64(v.Int()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		putUint(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		putUint(math.Float64bits(v.Float()))
+	case reflect.String:
+		putUint(uint64(v.Len()))
+		h.Write([]byte(v.String()))
+	case reflect.Map:
+		// Sum per-entry hashes so the result does not depend on map order.
+		var sum uint64
+		iter := v.MapRange()
+		for iter.Next() {
+			eh := fnv.New64a()
+			hashValue(eh, iter.Key())
+			hashValue(eh, iter.Value())
+			sum += eh.Sum64()
+		}
+		putUint(uint64(v.Len()))
+		putUint(sum)
+	case reflect.Slice, reflect.Array:
+		putUint(uint64(v.Len()))
+		for i := 0; i < v.Len(); i++ {
+			hashValue(h, v.Index(i))
+		}
+	default:
+		panic("rfx(apis): Config.Hash: unsupported field kind " + v.Kind().String())
+	}
 }
@@ -0,0 +1,73 @@
+/*
+   Copyright 2025 The DIRPX Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package apis_test
+
+import (
+	"testing"
+
+	"dirpx.dev/rfx/apis"
+)
+
+func TestConfig_EqualAndHash(t *testing.T) {
+	base := func() apis.Config {
+		return apis.Config{
+			IncludeBuiltins: true,
+			MaxUnwrap:       8,
+			NameStyle:       apis.NameStyleSnakeCase,
+			GlobalPrefix:    "tenant-a",
+			TypeAliases:     map[string]string{"time.Time": "timestamp", "uuid.UUID": "id"},
+		}
+	}
+
+	a, b := base(), base()
+	if !a.Equal(b) || a.Hash() != b.Hash() {
+		t.Fatalf("identical configs: Equal=%v, hashes %x and %x", a.Equal(b), a.Hash(), b.Hash())
+	}
+	if a.Hash() != a.Hash() {
+		t.Fatal("Hash is not stable across calls")
+	}
+
+	// Map entries inserted in a different order are still equal.
+	b.TypeAliases = map[string]string{"uuid.UUID": "id"}
+	b.TypeAliases["time.Time"] = "timestamp"
+	if !a.Equal(b) || a.Hash() != b.Hash() {
+		t.Fatal("configs differing only in map insertion order should be equal")
+	}
+
+	// Configs differing only in a map entry are not.
+	b.TypeAliases["uuid.UUID"] = "uuid"
+	if a.Equal(b) || a.Hash() == b.Hash() {
+		t.Fatal("configs differing in a map value should differ")
+	}
+	delete(b.TypeAliases, "uuid.UUID")
+	if a.Equal(b) {
+		t.Fatal("configs differing in map size should differ")
+	}
+
+	// Scalar fields count too.
+	c := base()
+	c.GlobalPrefix = "tenant-b"
+	if a.Equal(c) || a.Hash() == c.Hash() {
+		t.Fatal("configs differing in GlobalPrefix should differ")
+	}
+
+	// A nil map equals an empty one, since both disable remapping.
+	n, e := apis.Config{}, apis.Config{TypeAliases: map[string]string{}}
+	if !n.Equal(e) || n.Hash() != e.Hash() {
+		t.Fatal("nil and empty TypeAliases should be equal")
+	}
+}
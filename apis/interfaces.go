@@ -22,3 +22,46 @@ type Namer interface {
 	// EntityName returns the name of the entity.
 	EntityName() string
 }
+
+// Describer extends Namer with descriptive metadata about the entity.
+// Strategies may use it to enrich or qualify resolved names.
+type Describer interface {
+	Namer
+	// EntityVersion returns the schema version of the entity (e.g. "v2"), or "".
+	EntityVersion() string
+	// EntityCategory returns the category the entity belongs to, or "".
+	EntityCategory() string
+	// EntityDescription returns a human-readable description of the entity, or "".
+	EntityDescription() string
+}
+
+// Description is the metadata a Describer reports, as a value, so it can be
+// registered for types that do not implement Describer (e.g. from generated
+// code); see MetadataRegistry.
+type Description struct {
+	// Name is the entity name.
+	Name string
+	// Version is the schema version of the entity (e.g. "v2"), or "".
+	Version string
+	// Category is the category the entity belongs to, or "".
+	Category string
+	// Description is a human-readable description of the entity, or "".
+	Description string
+}
+
+// LocalizedDescriber is an optional companion to Describer for entities with
+// descriptions in several languages. Choosing the locale is the caller's
+// responsibility; implementations should fall back to a default language for
+// locales they do not know rather than returning "".
+type LocalizedDescriber interface {
+	// EntityDescriptionFor returns the description of the entity in the
+	// given locale (e.g. "en", "de-CH"), or "".
+	EntityDescriptionFor(locale string) string
+}
+
+// Identifier is implemented by values that carry a per-instance identity,
+// as opposed to the per-type name reported by Namer.
+type Identifier interface {
+	// EntityID returns the identifier of this particular entity, or "".
+	EntityID() string
+}
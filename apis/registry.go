@@ -16,7 +16,10 @@
 
 package apis
 
-import "reflect"
+import (
+	"io"
+	"reflect"
+)
 
 // Registry provides an optional reflection-free lookup for known types.
 // Keep it minimal so implementations can be lock-free or sync.Map-backed.
@@ -41,3 +44,129 @@ type Entry struct {
 	// Name is the associated name.
 	Name string
 }
+
+// DisplayRegistry is an optional extension of Registry that keeps a
+// human-readable display name (e.g. "User Account") next to the canonical name.
+// Display names are for presentation only; resolution keeps returning canonical names.
+type DisplayRegistry interface {
+	Registry
+	// RegisterDisplay registers t under canonical (like Register) and associates display with it.
+	RegisterDisplay(t reflect.Type, canonical, display string) error
+	// LookupDisplay returns the display name for a type if present.
+	LookupDisplay(t reflect.Type) (display string, ok bool)
+}
+
+// MetadataRegistry is an optional extension of Registry that keeps a
+// Description next to the canonical name of a type.
+type MetadataRegistry interface {
+	Registry
+	// RegisterDescribed registers t under d.Name (like Register) and
+	// associates d with it.
+	RegisterDescribed(t reflect.Type, d Description) error
+	// LookupDescription returns the description registered for t if present.
+	LookupDescription(t reflect.Type) (d Description, ok bool)
+}
+
+// RegistrySnapshot is an immutable, point-in-time view of a Registry that can
+// be shared freely between goroutines. It does not reflect later registrations.
+type RegistrySnapshot interface {
+	// Lookup returns a name for a type if present in the snapshot.
+	Lookup(t reflect.Type) (name string, ok bool)
+	// Len returns the number of entries in the snapshot.
+	Len() int
+	// Names returns the registered names in sorted order.
+	// The slice is shared and must not be modified.
+	Names() []string
+}
+
+// Snapshotter is an optional extension of Registry for registries that can
+// produce a RegistrySnapshot.
+type Snapshotter interface {
+	// Snapshot builds an immutable view of the current entries.
+	Snapshot() RegistrySnapshot
+}
+
+// LazyRegistry is an optional extension of Registry for registries that can
+// defer computing a name until the type is first looked up.
+type LazyRegistry interface {
+	// RegisterLazy associates t with the name returned by fn, which is
+	// called on the first Lookup of t instead of now.
+	RegisterLazy(t reflect.Type, fn func() (string, error)) error
+	// PendingLazy returns the lazy registrations not resolved yet, in
+	// unspecified order, so builders can carry them over on rebuild.
+	PendingLazy() []LazyEntry
+}
+
+// LazyEntry is a pending lazy registration reported by LazyRegistry.
+type LazyEntry struct {
+	// Type is the registered reflect.Type.
+	Type reflect.Type
+	// Fn computes the name on first lookup.
+	Fn func() (string, error)
+}
+
+// EntryWriter is an optional extension of Registry for registries that can
+// stream their entries without materializing them.
+type EntryWriter interface {
+	// WriteEntries writes every entry to w in the given format, e.g. "jsonl".
+	WriteEntries(w io.Writer, format string) error
+}
+
+// PrefixQuerier is an optional extension of Registry for registries that can
+// filter entries by name prefix, e.g. to count the entities under "domain.".
+// An empty prefix matches every entry.
+type PrefixQuerier interface {
+	// CountByPrefix returns the number of entries whose name has prefix.
+	CountByPrefix(prefix string) int
+	// EntriesByPrefix returns the entries whose name has prefix, in
+	// unspecified order.
+	EntriesByPrefix(prefix string) []Entry
+}
+
+// RegistryEventKind identifies the mutation reported by a RegistryEvent.
+type RegistryEventKind int
+
+const (
+	// EventRegistered reports a new (type, name) association.
+	EventRegistered RegistryEventKind = iota
+	// EventUnregistered reports the removal of an association.
+	EventUnregistered
+	// EventReset reports that all entries were cleared.
+	EventReset
+)
+
+// RegistryEvent describes a single mutation of a Registry.
+type RegistryEvent struct {
+	// Kind is the kind of mutation.
+	Kind RegistryEventKind
+	// Type is the affected (normalized) type; nil for EventReset.
+	Type reflect.Type
+	// Name is the affected name; "" for EventReset.
+	Name string
+}
+
+// Subscriber is an optional extension of Registry for registries that report
+// their mutations.
+type Subscriber interface {
+	// Subscribe registers fn to be called after each mutation and returns a
+	// function that removes the subscription. fn is called synchronously on
+	// the mutating goroutine, outside the registry's locks, so it may use the
+	// registry but should return quickly.
+	Subscribe(fn func(ev RegistryEvent)) (unsubscribe func())
+}
+
+// Freezer is an optional extension of Registry for registries that can be
+// made read-only for good.
+type Freezer interface {
+	// Freeze makes every later write fail, or do nothing for writes without
+	// an error result. Reads are unaffected. It cannot be undone.
+	Freeze()
+}
+
+// Unregisterer is an optional extension of Registry for registries that
+// support removing a single association.
+type Unregisterer interface {
+	// Unregister removes the association for the nearest named type of t and
+	// reports whether one existed.
+	Unregister(t reflect.Type) bool
+}
@@ -17,6 +17,7 @@
 package apis
 
 import (
+	"context"
 	"reflect"
 )
 
@@ -29,3 +30,44 @@ type Resolver interface {
 	// ResolveType returns a stable name for t, or "" if none can be determined.
 	ResolveType(t reflect.Type, cfg Config) string
 }
+
+// StrategyLister is an optional interface for resolvers that can enumerate
+// the strategies they consult.
+type StrategyLister interface {
+	// Strategies returns the strategies in resolution order.
+	Strategies() []Strategy
+}
+
+// EmptySkipper is an optional interface for StrategyLister resolvers that
+// treat an empty name from a handling strategy as a miss and continue with
+// the next strategy. Code walking Strategies directly must honour it to
+// resolve like the resolver does.
+type EmptySkipper interface {
+	// SkipsEmpty reports whether handled-but-empty results are skipped.
+	SkipsEmpty() bool
+}
+
+// SourceResolver is an optional interface for StrategyLister resolvers that
+// can report which of their strategies produced a name, so diagnostics can
+// attribute a result without running strategies outside the resolver.
+type SourceResolver interface {
+	StrategyLister
+
+	// ResolveSource resolves v exactly like Resolve and also returns the index
+	// in Strategies of the strategy that produced the name, or -1 if none did.
+	ResolveSource(v any, cfg Config) (string, int)
+
+	// ResolveTypeSource is the ResolveType variant of ResolveSource.
+	ResolveTypeSource(t reflect.Type, cfg Config) (string, int)
+
+	// ResolveCtxSource is the ResolveCtx variant of ResolveSource; resolvers
+	// without context support resolve like ResolveSource.
+	ResolveCtxSource(ctx context.Context, v any, cfg Config) (string, int)
+}
+
+// ContextResolver is an optional interface for resolvers that pass a context
+// down to strategies implementing ContextStrategy.
+type ContextResolver interface {
+	// ResolveCtx is Resolve with the caller's context.
+	ResolveCtx(ctx context.Context, v any, cfg Config) string
+}
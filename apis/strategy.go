@@ -17,6 +17,7 @@
 package apis
 
 import (
+	"context"
 	"reflect"
 )
 
@@ -30,3 +31,26 @@ type Strategy interface {
 	// TryResolveType attempts to resolve a name for the reflect.Type t.
 	TryResolveType(t reflect.Type, cfg Config) (name string, handled bool)
 }
+
+// NamedStrategy is an optional interface for strategies that report a short,
+// stable name for introspection (e.g. "namer", "registry", "reflect").
+type NamedStrategy interface {
+	// Name returns the strategy name.
+	Name() string
+}
+
+// TypeResolvable is an optional marker for strategies. A strategy whose
+// TypeResolvable reports false never handles TryResolveType (e.g. it needs an
+// instance), so resolvers may skip it when resolving types.
+type TypeResolvable interface {
+	// TypeResolvable reports whether TryResolveType can ever handle a type.
+	TypeResolvable() bool
+}
+
+// ContextStrategy is an optional interface for strategies that read
+// request-scoped values (e.g. a tenant) from a context. Resolvers that
+// implement ContextResolver call TryResolveCtx instead of TryResolve.
+type ContextStrategy interface {
+	// TryResolveCtx is TryResolve with the caller's context.
+	TryResolveCtx(ctx context.Context, v any, cfg Config) (name string, handled bool)
+}
@@ -0,0 +1,79 @@
+package rfx
+
+import (
+	"testing"
+	"time"
+
+	"dirpx.dev/rfx/apis"
+	"dirpx.dev/rfx/builder"
+	"dirpx.dev/rfx/config"
+)
+
+type churnType struct{}
+
+// TestEntity_DoesNotBlockOnWriters asserts that reads never wait for writers:
+// Entity completes while the writer lock is held.
+func TestEntity_DoesNotBlockOnWriters(t *testing.T) {
+	resetWithBuilder(t, builder.New(), config.DefaultConfig(), nil)
+
+	buildMu.Lock()
+	defer buildMu.Unlock()
+
+	done := make(chan string, 1)
+	go func() { done <- Entity(churnType{}) }()
+	select {
+	case got := <-done:
+		if got != "rfx.churnType" {
+			t.Fatalf("Entity() = %q, want %q", got, "rfx.churnType")
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Entity blocked while a writer held the lock")
+	}
+}
+
+// BenchmarkEntity_UnderWriterChurn measures parallel Entity latency while a
+// background goroutine republishes the snapshot via SetConfig every 100µs.
+// Compare with BenchmarkEntity_NoChurn to see the cost of writer churn.
+func BenchmarkEntity_UnderWriterChurn(b *testing.B) {
+	defer Capture().Restore()
+	resetWithBuilder(b, builder.New(), config.DefaultConfig(), nil)
+
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		tick := time.NewTicker(100 * time.Microsecond)
+		defer tick.Stop()
+		for i := 0; ; i++ {
+			select {
+			case <-stop:
+				return
+			case <-tick.C:
+				SetConfig(apis.Config{MaxUnwrap: 4 + i%4})
+			}
+		}
+	}()
+
+	benchmarkEntityParallel(b)
+
+	close(stop)
+	<-done
+}
+
+// BenchmarkEntity_NoChurn is the baseline for BenchmarkEntity_UnderWriterChurn.
+func BenchmarkEntity_NoChurn(b *testing.B) {
+	defer Capture().Restore()
+	resetWithBuilder(b, builder.New(), config.DefaultConfig(), nil)
+	benchmarkEntityParallel(b)
+}
+
+// benchmarkEntityParallel runs Entity from GOMAXPROCS goroutines.
+func benchmarkEntityParallel(b *testing.B) {
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			_ = Entity(churnType{})
+		}
+	})
+}
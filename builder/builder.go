@@ -20,37 +20,115 @@ import (
 	"dirpx.dev/rfx/apis"
 	"dirpx.dev/rfx/registry"
 	"dirpx.dev/rfx/resolver"
-	"dirpx.dev/rfx/strategy"
 )
 
+// Option configures a builder created with New.
+type Option func(*builder)
+
+// WithoutMigration makes BuildRegistry ignore the previous registry, so every
+// rebuild (e.g. on a config change) starts from an empty registry and
+// runtime registrations do not survive it.
+func WithoutMigration() Option {
+	return func(b *builder) {
+		b.noMigration = true
+	}
+}
+
+// WithRegistryOverNamer makes BuildResolver consult the registry before the
+// Namer strategy (Registry -> Namer -> Reflect with the default order), so
+// explicit registrations override a type's own EntityName. It has the same
+// effect as apis.Config.RegistryOverridesNamer, but fixed for the builder.
+func WithRegistryOverNamer(enabled bool) Option {
+	return func(b *builder) {
+		b.registryOverNamer = enabled
+	}
+}
+
+// WithStrategyOrder fixes the order in which this builder assembles
+// strategies, instead of following the process-wide SetStrategyOrder.
+// Every kind must already be registered; New panics with an error wrapping
+// ErrUnknownStrategyKind or ErrEmptyStrategyOrder otherwise.
+func WithStrategyOrder(order ...StrategyKind) Option {
+	return func(b *builder) {
+		if len(order) == 0 {
+			panic(ErrEmptyStrategyOrder)
+		}
+		kindsMu.RLock()
+		err := checkKindsLocked(order)
+		kindsMu.RUnlock()
+		if err != nil {
+			panic(err)
+		}
+		b.order = append([]StrategyKind(nil), order...)
+	}
+}
+
 // New creates and returns a new instance of an apis.Builder.
-func New() apis.Builder {
-	return &builder{}
+func New(opts ...Option) apis.Builder {
+	b := &builder{}
+	for _, opt := range opts {
+		opt(b)
+	}
+	return b
 }
 
-// builder is an empty struct to be used as a receiver for builder methods.
-type builder struct{}
+// builder is the default apis.Builder.
+type builder struct {
+	// noMigration disables copying prev registry entries on rebuild.
+	noMigration bool
+	// registryOverNamer places the registry strategy before the Namer strategy.
+	registryOverNamer bool
+	// order is the strategy order; nil follows the global StrategyOrder.
+	order []StrategyKind
+}
 
 // BuildRegistry builds and returns a new apis.Registry based on the provided configuration
 // and pre-existing registry. If a pre-existing registry is provided, its entries are copied
-// into the new registry.
+// into the new registry, together with display names if it is an apis.DisplayRegistry
+// and descriptions if it is an apis.MetadataRegistry, as well as pending lazy
+// registrations of an apis.LazyRegistry, unless the builder was created WithoutMigration.
+// Entries are copied in the order preg reports them; the registries in package
+// registry report registration order, so generic-instantiation fallbacks keep
+// their first registered instantiation.
 func (b *builder) BuildRegistry(cfg apis.Config, preg apis.Registry, _ any) apis.Registry {
 	nreg := registry.New(cfg)
-	if preg != nil {
+	if preg != nil && !b.noMigration {
+		pdisp, _ := preg.(apis.DisplayRegistry)
+		ndisp := nreg.(apis.DisplayRegistry)
+		pmeta, _ := preg.(apis.MetadataRegistry)
+		nmeta := nreg.(apis.MetadataRegistry)
 		for _, e := range preg.Entries() {
+			if pmeta != nil {
+				if d, ok := pmeta.LookupDescription(e.Type); ok {
+					_ = nmeta.RegisterDescribed(e.Type, d)
+				}
+			}
+			if pdisp != nil {
+				if display, ok := pdisp.LookupDisplay(e.Type); ok {
+					_ = ndisp.RegisterDisplay(e.Type, e.Name, display)
+					continue
+				}
+			}
 			_ = nreg.Register(e.Type, e.Name)
 		}
+		// Carry over lazy registrations that have not been resolved yet.
+		if plazy, ok := preg.(apis.LazyRegistry); ok {
+			nlazy := nreg.(apis.LazyRegistry)
+			for _, e := range plazy.PendingLazy() {
+				_ = nlazy.RegisterLazy(e.Type, e.Fn)
+			}
+		}
 	}
 	return nreg
 }
 
 // BuildResolver builds and returns a new apis.Resolver based on the provided configuration,
 // registry, and pre-existing resolver. If a pre-existing resolver is provided, its state
-// may be reused in the new resolver.
+// may be reused in the new resolver. Strategies are assembled in the order set
+// with WithStrategyOrder, or else in StrategyOrder().
 func (b *builder) BuildResolver(cfg apis.Config, reg apis.Registry, _ apis.Resolver, _ any) apis.Resolver {
-	return resolver.New(
-		strategy.NewNamerStrategy(),
-		strategy.NewRegistryStrategy(reg),
-		strategy.NewReflectStrategy(),
-	)
+	if b.registryOverNamer {
+		cfg.RegistryOverridesNamer = true
+	}
+	return resolver.WithPrefix(resolver.New(buildStrategies(b.order, cfg, reg)...), cfg.GlobalPrefix)
 }
@@ -129,6 +129,59 @@ func TestBuildResolver_Order_NamerThenRegistryThenReflect(t *testing.T) {
 	}
 }
 
+// TestBuildResolver_RegistryOverridesNamer verifies that the flag moves the
+// registry strategy ahead of the Namer strategy, and only then.
+func TestBuildResolver_RegistryOverridesNamer(t *testing.T) {
+	b := builder.New()
+	cfg := defaultCfg()
+	reg := b.BuildRegistry(cfg, nil, nil)
+	if err := reg.Register(reflect.TypeOf(hotType{}), "renamed-hot"); err != nil {
+		t.Fatalf("Register(hotType) failed: %v", err)
+	}
+
+	if got := b.BuildResolver(cfg, reg, nil, nil).Resolve(hotType{}, cfg); got != "hot-name" {
+		t.Fatalf("default priority: got %q want %q", got, "hot-name")
+	}
+
+	cfg.RegistryOverridesNamer = true
+	res := b.BuildResolver(cfg, reg, nil, nil)
+	if got := res.Resolve(hotType{}, cfg); got != "renamed-hot" {
+		t.Fatalf("registry override: got %q want %q", got, "renamed-hot")
+	}
+	// Unregistered Namers still resolve through EntityName.
+	type otherHot struct{ hotType }
+	if got := res.Resolve(otherHot{}, cfg); got != "hot-name" {
+		t.Fatalf("unregistered Namer: got %q want %q", got, "hot-name")
+	}
+	if got, want := strategyNames(t, res), []string{"registry", "namer", "reflect"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("strategies = %v, want %v", got, want)
+	}
+}
+
+// TestBuildResolver_WithRegistryOverNamer verifies the builder option has the
+// same effect as the config flag.
+func TestBuildResolver_WithRegistryOverNamer(t *testing.T) {
+	b := builder.New(builder.WithRegistryOverNamer(true))
+	cfg := defaultCfg()
+	reg := b.BuildRegistry(cfg, nil, nil)
+	if err := reg.Register(reflect.TypeOf(hotType{}), "vendor-renamed"); err != nil {
+		t.Fatalf("Register(hotType) failed: %v", err)
+	}
+
+	res := b.BuildResolver(cfg, reg, nil, nil)
+	if got := res.Resolve(hotType{}, cfg); got != "vendor-renamed" {
+		t.Fatalf("registry over namer: got %q want %q", got, "vendor-renamed")
+	}
+	if got, want := strategyNames(t, res), []string{"registry", "namer", "reflect"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("strategies = %v, want %v", got, want)
+	}
+
+	off := builder.New(builder.WithRegistryOverNamer(false))
+	if got := off.BuildResolver(cfg, reg, nil, nil).Resolve(hotType{}, cfg); got != "hot-name" {
+		t.Fatalf("option off: got %q want %q", got, "hot-name")
+	}
+}
+
 // TestBuildResolver_WithExternalRegistry asserts that BuildResolver will
 // accept *any* apis.Registry implementation (not only the one created by
 // this builder), and still resolve names from it.
@@ -199,3 +252,67 @@ func TestBuildResolver_Concurrency_Smoke(t *testing.T) {
 
 // Compile-time check: builder.New() must satisfy apis.Builder.
 var _ apis.Builder = builder.New()
+
+// TestBuildRegistry_MigratesDisplayNames asserts that display names survive a rebuild.
+func TestBuildRegistry_MigratesDisplayNames(t *testing.T) {
+	b := builder.New()
+	prev := b.BuildRegistry(defaultCfg(), nil, nil).(apis.DisplayRegistry)
+	if err := prev.RegisterDisplay(reflect.TypeOf(userType{}), "domain.user", "User"); err != nil {
+		t.Fatalf("RegisterDisplay: %v", err)
+	}
+
+	next := b.BuildRegistry(defaultCfg(), prev, nil).(apis.DisplayRegistry)
+	if got, ok := next.LookupDisplay(reflect.TypeOf(userType{})); !ok || got != "User" {
+		t.Fatalf("LookupDisplay after rebuild: got (%q,%v), want (User,true)", got, ok)
+	}
+	if got, ok := next.Lookup(reflect.TypeOf(userType{})); !ok || got != "domain.user" {
+		t.Fatalf("Lookup after rebuild: got (%q,%v), want (domain.user,true)", got, ok)
+	}
+}
+
+// genType is a generic type used to test the generic-instantiation fallback.
+type genType[T any] struct{ V T }
+
+// TestBuildRegistry_MigratesGenericFallback asserts that the first registered
+// instantiation still provides the shared name after a rebuild.
+func TestBuildRegistry_MigratesGenericFallback(t *testing.T) {
+	b := builder.New()
+	prev := b.BuildRegistry(defaultCfg(), nil, nil)
+	for _, e := range []apis.Entry{
+		{Type: reflect.TypeOf(genType[int]{}), Name: "gen.int"},
+		{Type: reflect.TypeOf(genType[bool]{}), Name: "gen.bool"},
+		{Type: reflect.TypeOf(genType[uint]{}), Name: "gen.uint"},
+		{Type: reflect.TypeOf(genType[int8]{}), Name: "gen.int8"},
+		{Type: reflect.TypeOf(genType[int16]{}), Name: "gen.int16"},
+	} {
+		if err := prev.Register(e.Type, e.Name); err != nil {
+			t.Fatalf("Register: %v", err)
+		}
+	}
+
+	// Map iteration is random, so rebuild a few times.
+	for i := 0; i < 10; i++ {
+		next := b.BuildRegistry(defaultCfg(), prev, nil)
+		if got, _ := next.Lookup(reflect.TypeOf(genType[string]{})); got != "gen.int" {
+			t.Fatalf("rebuild %d: Lookup(genType[string]) = %q, want gen.int", i, got)
+		}
+		prev = next
+	}
+}
+
+// TestBuildRegistry_WithoutMigration asserts that prev entries are dropped.
+func TestBuildRegistry_WithoutMigration(t *testing.T) {
+	b := builder.New(builder.WithoutMigration())
+	prev := b.BuildRegistry(defaultCfg(), nil, nil)
+	if err := prev.Register(reflect.TypeOf(userType{}), "domain.user"); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+
+	next := b.BuildRegistry(defaultCfg(), prev, nil)
+	if next.Count() != 0 {
+		t.Fatalf("Count after rebuild = %d, want 0", next.Count())
+	}
+	if _, ok := next.Lookup(reflect.TypeOf(userType{})); ok {
+		t.Fatal("entry should not be migrated")
+	}
+}
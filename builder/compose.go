@@ -0,0 +1,73 @@
+/*
+   Copyright 2025 The DIRPX Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package builder
+
+import (
+	"dirpx.dev/rfx/apis"
+	"dirpx.dev/rfx/registry"
+	"dirpx.dev/rfx/resolver"
+)
+
+// Compose returns an apis.Builder that combines several builders, so that
+// independent modules can each own a builder.
+//
+// BuildRegistry builds every builder's registry and merges their entries into
+// a single registry; on conflicting names for the same type the first builder
+// wins. BuildResolver builds every builder's resolver against the merged
+// registry and chains them with resolver.Fallback. Nil builders are ignored.
+func Compose(builders ...apis.Builder) apis.Builder {
+	out := make([]apis.Builder, 0, len(builders))
+	for _, b := range builders {
+		if b != nil {
+			out = append(out, b)
+		}
+	}
+	return &composite{blds: out}
+}
+
+// composite merges registries and chains resolvers of several builders.
+type composite struct {
+	blds []apis.Builder
+}
+
+// Ensure composite implements apis.Builder.
+var _ apis.Builder = (*composite)(nil)
+
+// BuildRegistry merges the registries built by each builder (first wins).
+func (c *composite) BuildRegistry(cfg apis.Config, preg apis.Registry, ext any) apis.Registry {
+	nreg := registry.New(cfg)
+	for _, b := range c.blds {
+		reg := b.BuildRegistry(cfg, preg, ext)
+		if reg == nil {
+			continue
+		}
+		for _, e := range reg.Entries() {
+			// Conflicts keep the earlier builder's name.
+			_ = nreg.Register(e.Type, e.Name)
+		}
+	}
+	return nreg
+}
+
+// BuildResolver chains the resolvers built by each builder via resolver.Fallback.
+func (c *composite) BuildResolver(cfg apis.Config, reg apis.Registry, _ apis.Resolver, ext any) apis.Resolver {
+	ress := make([]apis.Resolver, 0, len(c.blds))
+	for _, b := range c.blds {
+		ress = append(ress, b.BuildResolver(cfg, reg, nil, ext))
+	}
+	return resolver.Fallback(ress...)
+}
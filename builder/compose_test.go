@@ -0,0 +1,90 @@
+/*
+   Copyright 2025 The DIRPX Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package builder_test
+
+import (
+	"reflect"
+	"testing"
+
+	apis "dirpx.dev/rfx/apis"
+	"dirpx.dev/rfx/builder"
+	"dirpx.dev/rfx/registry"
+)
+
+// seededBuilder is the default builder plus a fixed set of registrations.
+type seededBuilder struct {
+	apis.Builder
+	seed map[reflect.Type]string
+}
+
+func (b seededBuilder) BuildRegistry(cfg apis.Config, prev apis.Registry, ext any) apis.Registry {
+	reg := b.Builder.BuildRegistry(cfg, prev, ext)
+	for t, name := range b.seed {
+		_ = reg.Register(t, name)
+	}
+	return reg
+}
+
+type domainType struct{}
+type infraType struct{}
+
+func TestCompose_MergesRegistriesFirstWins(t *testing.T) {
+	domain := seededBuilder{Builder: builder.New(), seed: map[reflect.Type]string{
+		reflect.TypeOf(domainType{}): "domain.type",
+		reflect.TypeOf(userType{}):   "domain.user",
+	}}
+	infra := seededBuilder{Builder: builder.New(), seed: map[reflect.Type]string{
+		reflect.TypeOf(infraType{}): "infra.type",
+		reflect.TypeOf(userType{}):  "infra.user",
+	}}
+
+	b := builder.Compose(domain, nil, infra)
+	cfg := defaultCfg()
+	reg := b.BuildRegistry(cfg, nil, nil)
+
+	if reg.Count() != 3 {
+		t.Fatalf("Count() = %d, want 3", reg.Count())
+	}
+	want := map[reflect.Type]string{
+		reflect.TypeOf(domainType{}): "domain.type",
+		reflect.TypeOf(infraType{}):  "infra.type",
+		reflect.TypeOf(userType{}):   "domain.user",
+	}
+	for tt, name := range want {
+		if got, ok := reg.Lookup(tt); !ok || got != name {
+			t.Fatalf("Lookup(%v) = (%q,%v), want (%q,true)", tt, got, ok, name)
+		}
+	}
+
+	res := b.BuildResolver(cfg, reg, nil, nil)
+	if got := res.ResolveType(reflect.TypeOf(infraType{}), cfg); got != "infra.type" {
+		t.Fatalf("ResolveType(infraType) = %q, want %q", got, "infra.type")
+	}
+	if got := res.Resolve(hotType{}, cfg); got != "hot-name" {
+		t.Fatalf("Resolve(hotType) = %q, want %q", got, "hot-name")
+	}
+}
+
+func TestCompose_MigratesPrevious(t *testing.T) {
+	prev := registry.New(defaultCfg())
+	_ = prev.Register(reflect.TypeOf(userType{}), "prev.user")
+
+	reg := builder.Compose(builder.New(), builder.New()).BuildRegistry(defaultCfg(), prev, nil)
+	if got, ok := reg.Lookup(reflect.TypeOf(userType{})); !ok || got != "prev.user" {
+		t.Fatalf("Lookup = (%q,%v), want (prev.user,true)", got, ok)
+	}
+}
@@ -0,0 +1,187 @@
+/*
+   Copyright 2025 The DIRPX Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package builder
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"dirpx.dev/rfx/apis"
+	"dirpx.dev/rfx/strategy"
+)
+
+// StrategyKind names a strategy the default builder can place in its chain.
+type StrategyKind string
+
+const (
+	// KindNamer is the apis.Namer fast path.
+	KindNamer StrategyKind = "namer"
+	// KindRegistry consults the registry passed to BuildResolver.
+	KindRegistry StrategyKind = "registry"
+	// KindReflect is the reflection-based fallback.
+	KindReflect StrategyKind = "reflect"
+)
+
+// StrategyFactory constructs a strategy for the given configuration and registry.
+type StrategyFactory func(cfg apis.Config, reg apis.Registry) apis.Strategy
+
+var (
+	// ErrUnknownStrategyKind is returned when a strategy order references
+	// a kind that was never registered.
+	ErrUnknownStrategyKind = errors.New("rfx(builder): unknown strategy kind")
+	// ErrEmptyStrategyOrder is returned when an empty strategy order is set.
+	ErrEmptyStrategyOrder = errors.New("rfx(builder): empty strategy order")
+)
+
+// RegisterStrategyKind registers a named strategy kind so it can be used in
+// SetStrategyOrder. It is intended to be called from init functions.
+// It panics if name is empty, factory is nil, or the kind is already registered.
+func RegisterStrategyKind(name string, factory StrategyFactory) StrategyKind {
+	if name == "" {
+		panic("rfx(builder): RegisterStrategyKind with empty name")
+	}
+	if factory == nil {
+		panic("rfx(builder): RegisterStrategyKind with nil factory for " + name)
+	}
+
+	kindsMu.Lock()
+	defer kindsMu.Unlock()
+
+	k := StrategyKind(name)
+	if _, dup := kinds[k]; dup {
+		panic("rfx(builder): RegisterStrategyKind called twice for " + name)
+	}
+	kinds[k] = factory
+	return k
+}
+
+// SetStrategyOrder sets the process-wide order in which builders created by
+// New without WithStrategyOrder assemble strategies. Every kind must be
+// registered. On error the current order is left unchanged.
+//
+// Resolvers that were already built keep their order: the new order only
+// applies to subsequent BuildResolver calls, so the global rfx resolver
+// picks it up on its next rebuild (e.g. rfx.SetBuilder or rfx.SetConfig).
+// Prefer WithStrategyOrder, which scopes the order to one builder.
+func SetStrategyOrder(order []StrategyKind) error {
+	if len(order) == 0 {
+		return ErrEmptyStrategyOrder
+	}
+
+	kindsMu.Lock()
+	defer kindsMu.Unlock()
+
+	if err := checkKindsLocked(order); err != nil {
+		return err
+	}
+	strategyOrder = append([]StrategyKind(nil), order...)
+	return nil
+}
+
+// checkKindsLocked reports the first kind in order that is not registered;
+// kindsMu must be held.
+func checkKindsLocked(order []StrategyKind) error {
+	for _, k := range order {
+		if _, ok := kinds[k]; !ok {
+			return fmt.Errorf("%w: %q", ErrUnknownStrategyKind, k)
+		}
+	}
+	return nil
+}
+
+// StrategyOrder returns a copy of the order used by the default builder.
+func StrategyOrder() []StrategyKind {
+	kindsMu.RLock()
+	defer kindsMu.RUnlock()
+	return append([]StrategyKind(nil), strategyOrder...)
+}
+
+// DefaultStrategyOrder returns the built-in order: Namer -> Registry -> Reflect.
+func DefaultStrategyOrder() []StrategyKind {
+	return []StrategyKind{KindNamer, KindRegistry, KindReflect}
+}
+
+// kindsMu guards kinds and strategyOrder.
+var kindsMu sync.RWMutex
+
+// kinds maps registered strategy kinds to their factories.
+var kinds = map[StrategyKind]StrategyFactory{
+	KindNamer: func(apis.Config, apis.Registry) apis.Strategy {
+		return strategy.NewNamerStrategy()
+	},
+	KindRegistry: func(_ apis.Config, reg apis.Registry) apis.Strategy {
+		return strategy.NewRegistryStrategy(reg)
+	},
+	KindReflect: func(apis.Config, apis.Registry) apis.Strategy {
+		return strategy.NewReflectStrategy()
+	},
+}
+
+// strategyOrder is the order used by the default builder.
+var strategyOrder = DefaultStrategyOrder()
+
+// buildStrategies instantiates order, or the global strategy order if order
+// is nil, for cfg and reg. KindReflect is skipped when
+// cfg.DisableReflectFallback is set, and KindRegistry is moved ahead of
+// KindNamer when cfg.RegistryOverridesNamer is.
+func buildStrategies(order []StrategyKind, cfg apis.Config, reg apis.Registry) []apis.Strategy {
+	kindsMu.RLock()
+	defer kindsMu.RUnlock()
+
+	if order == nil {
+		order = strategyOrder
+	}
+	if cfg.RegistryOverridesNamer {
+		order = registryBeforeNamer(order)
+	}
+
+	out := make([]apis.Strategy, 0, len(order))
+	for _, k := range order {
+		if k == KindReflect && cfg.DisableReflectFallback {
+			continue
+		}
+		out = append(out, kinds[k](cfg, reg))
+	}
+	return out
+}
+
+// registryBeforeNamer returns order with KindRegistry moved directly in front
+// of KindNamer if it came after it; otherwise order is returned unchanged.
+func registryBeforeNamer(order []StrategyKind) []StrategyKind {
+	n, r := -1, -1
+	for i, k := range order {
+		switch k {
+		case KindNamer:
+			if n < 0 {
+				n = i
+			}
+		case KindRegistry:
+			if r < 0 {
+				r = i
+			}
+		}
+	}
+	if n < 0 || r < n {
+		return order
+	}
+	out := make([]StrategyKind, 0, len(order))
+	out = append(out, order[:n]...)
+	out = append(out, KindRegistry)
+	out = append(out, order[n:r]...)
+	return append(out, order[r+1:]...)
+}
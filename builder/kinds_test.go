@@ -0,0 +1,133 @@
+/*
+   Copyright 2025 The DIRPX Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package builder_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	apis "dirpx.dev/rfx/apis"
+	"dirpx.dev/rfx/builder"
+)
+
+// tagStrategy resolves every value and type to a fixed tag.
+type tagStrategy struct{}
+
+func (tagStrategy) Name() string                               { return "customtag" }
+func (tagStrategy) TryResolve(any, apis.Config) (string, bool) { return "tagged", true }
+func (tagStrategy) TryResolveType(reflect.Type, apis.Config) (string, bool) {
+	return "tagged", true
+}
+
+// kindCustomTag is registered once for the whole test binary.
+var kindCustomTag = builder.RegisterStrategyKind("customtag", func(apis.Config, apis.Registry) apis.Strategy {
+	return tagStrategy{}
+})
+
+// strategyNames returns the NamedStrategy names of res.
+func strategyNames(t *testing.T, res apis.Resolver) []string {
+	t.Helper()
+	l, ok := res.(apis.StrategyLister)
+	if !ok {
+		t.Fatalf("%T does not implement apis.StrategyLister", res)
+	}
+	var names []string
+	for _, s := range l.Strategies() {
+		names = append(names, s.(apis.NamedStrategy).Name())
+	}
+	return names
+}
+
+func TestStrategyOrder_Default(t *testing.T) {
+	if got, want := builder.StrategyOrder(), builder.DefaultStrategyOrder(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("StrategyOrder() = %v, want %v", got, want)
+	}
+}
+
+func TestSetStrategyOrder_CustomKindParticipates(t *testing.T) {
+	defer func() { _ = builder.SetStrategyOrder(builder.DefaultStrategyOrder()) }()
+
+	order := []builder.StrategyKind{builder.KindNamer, kindCustomTag, builder.KindReflect}
+	if err := builder.SetStrategyOrder(order); err != nil {
+		t.Fatalf("SetStrategyOrder: %v", err)
+	}
+
+	b := builder.New()
+	cfg := defaultCfg()
+	res := b.BuildResolver(cfg, b.BuildRegistry(cfg, nil, nil), nil, nil)
+
+	want := []string{"namer", "customtag", "reflect"}
+	if got := strategyNames(t, res); !reflect.DeepEqual(got, want) {
+		t.Fatalf("strategies = %v, want %v", got, want)
+	}
+	// Namer still wins; the custom kind shadows reflection for everything else.
+	if got := res.Resolve(hotType{}, cfg); got != "hot-name" {
+		t.Fatalf("Resolve(hotType) = %q, want %q", got, "hot-name")
+	}
+	if got := res.ResolveType(reflect.TypeOf(userType{}), cfg); got != "tagged" {
+		t.Fatalf("ResolveType(userType) = %q, want %q", got, "tagged")
+	}
+}
+
+func TestWithStrategyOrder_ScopedToBuilder(t *testing.T) {
+	b := builder.New(builder.WithStrategyOrder(kindCustomTag, builder.KindNamer))
+	cfg := defaultCfg()
+	res := b.BuildResolver(cfg, b.BuildRegistry(cfg, nil, nil), nil, nil)
+
+	if got, want := strategyNames(t, res), []string{"customtag", "namer"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("strategies = %v, want %v", got, want)
+	}
+	// The global order is untouched.
+	if got, want := builder.StrategyOrder(), builder.DefaultStrategyOrder(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("StrategyOrder() = %v, want %v", got, want)
+	}
+
+	defer func() {
+		err, _ := recover().(error)
+		if !errors.Is(err, builder.ErrUnknownStrategyKind) {
+			t.Fatalf("unknown kind: want panic with ErrUnknownStrategyKind, got %v", err)
+		}
+	}()
+	builder.New(builder.WithStrategyOrder("missing"))
+}
+
+func TestSetStrategyOrder_Errors(t *testing.T) {
+	before := builder.StrategyOrder()
+
+	err := builder.SetStrategyOrder([]builder.StrategyKind{builder.KindNamer, "missing"})
+	if !errors.Is(err, builder.ErrUnknownStrategyKind) {
+		t.Fatalf("unknown kind: want ErrUnknownStrategyKind, got %v", err)
+	}
+	if err := builder.SetStrategyOrder(nil); !errors.Is(err, builder.ErrEmptyStrategyOrder) {
+		t.Fatalf("empty order: want ErrEmptyStrategyOrder, got %v", err)
+	}
+	if got := builder.StrategyOrder(); !reflect.DeepEqual(got, before) {
+		t.Fatalf("order changed after failed SetStrategyOrder: %v, want %v", got, before)
+	}
+}
+
+func TestRegisterStrategyKind_DuplicatePanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("expected panic on duplicate registration")
+		}
+	}()
+	builder.RegisterStrategyKind(string(builder.KindNamer), func(apis.Config, apis.Registry) apis.Strategy {
+		return tagStrategy{}
+	})
+}
@@ -0,0 +1,192 @@
+/*
+   Copyright 2025 The DIRPX Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package builder
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+
+	"dirpx.dev/rfx/apis"
+	"dirpx.dev/rfx/registry"
+)
+
+// ErrInvalidName is returned (or raised) when a name is rejected by the
+// validator of a builder created with NewValidating.
+var ErrInvalidName = errors.New("rfx(builder): name rejected by validator")
+
+// MigrationMode controls how NewValidating treats entries that already exist
+// in a freshly built registry (typically migrated from the previous one)
+// but do not pass validation.
+type MigrationMode int
+
+const (
+	// MigrationLog keeps invalid migrated entries and reports each of them to
+	// the handler set with WithMigrationErrorHandler, if any.
+	MigrationLog MigrationMode = iota
+	// MigrationFail makes BuildRegistry reject the rebuild on the first
+	// invalid migrated entry: it reports that entry to the handler set with
+	// WithMigrationErrorHandler, if any, and returns nil. rfx.TrySetConfig and
+	// rfx.TrySetAll then fail with rfx.ErrNilRegistry and the previous
+	// registry stays in place; their Set counterparts panic with it.
+	MigrationFail
+)
+
+// ValidatingOption configures a builder created with NewValidating.
+type ValidatingOption func(*validatingBuilder)
+
+// WithMigrationMode sets how invalid migrated entries are handled.
+// The default is MigrationLog.
+func WithMigrationMode(mode MigrationMode) ValidatingOption {
+	return func(b *validatingBuilder) {
+		b.mode = mode
+	}
+}
+
+// WithMigrationErrorHandler sets fn to be called for each invalid migrated
+// entry that is kept in MigrationLog mode, or for the entry that rejects the
+// rebuild in MigrationFail mode. err wraps ErrInvalidName.
+func WithMigrationErrorHandler(fn func(t reflect.Type, err error)) ValidatingOption {
+	return func(b *validatingBuilder) {
+		b.onInvalid = fn
+	}
+}
+
+// NewValidating wraps inner so that every registry it builds enforces validate
+// on Register, and every entry carried over into a rebuilt registry is checked
+// according to the configured MigrationMode.
+// A nil inner falls back to New(); a nil validate returns inner unchanged.
+func NewValidating(inner apis.Builder, validate func(name string) error, opts ...ValidatingOption) apis.Builder {
+	if inner == nil {
+		inner = New()
+	}
+	if validate == nil {
+		return inner
+	}
+	b := &validatingBuilder{inner: inner, validate: validate}
+	for _, opt := range opts {
+		opt(b)
+	}
+	return b
+}
+
+// validatingBuilder decorates another apis.Builder with name validation.
+type validatingBuilder struct {
+	// inner builds the underlying registry and resolver.
+	inner apis.Builder
+	// validate reports whether a name conforms to the naming policy.
+	validate func(name string) error
+	// mode controls handling of invalid migrated entries.
+	mode MigrationMode
+	// onInvalid receives invalid migrated entries kept in MigrationLog mode.
+	onInvalid func(t reflect.Type, err error)
+}
+
+// Ensure validatingBuilder implements apis.Builder.
+var _ apis.Builder = (*validatingBuilder)(nil)
+
+// BuildRegistry delegates to the inner builder, validates all entries present
+// in the produced registry and wraps it so further registrations are validated.
+func (b *validatingBuilder) BuildRegistry(cfg apis.Config, preg apis.Registry, ext any) apis.Registry {
+	// Hand the raw previous registry to the inner builder to avoid stacking wrappers.
+	if vr, ok := preg.(*validatingRegistry); ok {
+		preg = vr.Registry
+	}
+	nreg := b.inner.BuildRegistry(cfg, preg, ext)
+	if nreg == nil {
+		return nil
+	}
+	if vr, ok := nreg.(*validatingRegistry); ok {
+		nreg = vr.Registry
+	}
+
+	for _, e := range nreg.Entries() {
+		if err := b.check(e.Name); err != nil {
+			if b.onInvalid != nil {
+				b.onInvalid(e.Type, err)
+			}
+			if b.mode == MigrationFail {
+				return nil
+			}
+		}
+	}
+	return &validatingRegistry{Wrapper: registry.Wrapper{Registry: nreg, Config: cfg}, check: b.check}
+}
+
+// BuildResolver delegates to the inner builder.
+func (b *validatingBuilder) BuildResolver(cfg apis.Config, reg apis.Registry, pres apis.Resolver, ext any) apis.Resolver {
+	return b.inner.BuildResolver(cfg, reg, pres, ext)
+}
+
+// check runs the validator and wraps its failure with ErrInvalidName.
+func (b *validatingBuilder) check(name string) error {
+	if err := b.validate(name); err != nil {
+		return fmt.Errorf("%w: %q: %w", ErrInvalidName, name, err)
+	}
+	return nil
+}
+
+// validatingRegistry rejects registrations whose names fail validation.
+// Everything else, including the optional registry interfaces, is forwarded
+// by the embedded registry.Wrapper.
+type validatingRegistry struct {
+	registry.Wrapper
+	// check validates a name before it is registered.
+	check func(name string) error
+}
+
+// Register validates name and, if accepted, delegates to the wrapped registry.
+func (r *validatingRegistry) Register(t reflect.Type, name string) error {
+	if err := r.check(name); err != nil {
+		return err
+	}
+	return r.Registry.Register(t, name)
+}
+
+// RegisterDisplay validates canonical and delegates to the wrapped registry.
+func (r *validatingRegistry) RegisterDisplay(t reflect.Type, canonical, display string) error {
+	if err := r.check(canonical); err != nil {
+		return err
+	}
+	return r.Wrapper.RegisterDisplay(t, canonical, display)
+}
+
+// RegisterDescribed validates d.Name and delegates to the wrapped registry.
+func (r *validatingRegistry) RegisterDescribed(t reflect.Type, d apis.Description) error {
+	if err := r.check(d.Name); err != nil {
+		return err
+	}
+	return r.Wrapper.RegisterDescribed(t, d)
+}
+
+// RegisterLazy delegates to the wrapped registry, validating the name when
+// fn is eventually called; a rejected name fails the lookup like an fn error.
+func (r *validatingRegistry) RegisterLazy(t reflect.Type, fn func() (string, error)) error {
+	if fn == nil {
+		return r.Wrapper.RegisterLazy(t, nil)
+	}
+	return r.Wrapper.RegisterLazy(t, func() (string, error) {
+		name, err := fn()
+		if err != nil {
+			return "", err
+		}
+		if err := r.check(name); err != nil {
+			return "", err
+		}
+		return name, nil
+	})
+}
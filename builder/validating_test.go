@@ -0,0 +1,162 @@
+/*
+   Copyright 2025 The DIRPX Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package builder_test
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	apis "dirpx.dev/rfx/apis"
+	"dirpx.dev/rfx/builder"
+	"dirpx.dev/rfx/registry"
+)
+
+// dottedName accepts names shaped like "domain.subdomain".
+func dottedName(name string) error {
+	parts := strings.Split(name, ".")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return errors.New("want domain.subdomain")
+	}
+	return nil
+}
+
+func TestValidating_RejectsInvalidRegister(t *testing.T) {
+	b := builder.NewValidating(builder.New(), dottedName)
+	reg := b.BuildRegistry(defaultCfg(), nil, nil)
+
+	tt := reflect.TypeOf(userType{})
+	err := reg.Register(tt, "nodots")
+	if !errors.Is(err, builder.ErrInvalidName) {
+		t.Fatalf("Register(nodots): want ErrInvalidName, got %v", err)
+	}
+	if _, ok := reg.Lookup(tt); ok {
+		t.Fatalf("rejected name must not be stored")
+	}
+
+	if err := reg.Register(tt, "domain.user"); err != nil {
+		t.Fatalf("Register(domain.user): unexpected error: %v", err)
+	}
+	if got, ok := reg.Lookup(tt); !ok || got != "domain.user" {
+		t.Fatalf("Lookup: got (%q,%v), want (domain.user,true)", got, ok)
+	}
+}
+
+func TestValidating_MigratedEntry_LogMode(t *testing.T) {
+	prev := registry.New(defaultCfg())
+	if err := prev.Register(reflect.TypeOf(userType{}), "legacy"); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+
+	var reported []reflect.Type
+	b := builder.NewValidating(builder.New(), dottedName,
+		builder.WithMigrationErrorHandler(func(t reflect.Type, err error) {
+			if errors.Is(err, builder.ErrInvalidName) {
+				reported = append(reported, t)
+			}
+		}))
+	reg := b.BuildRegistry(defaultCfg(), prev, nil)
+
+	// Lenient mode keeps the invalid migrated entry and reports it.
+	if got, ok := reg.Lookup(reflect.TypeOf(userType{})); !ok || got != "legacy" {
+		t.Fatalf("migrated entry: got (%q,%v), want (legacy,true)", got, ok)
+	}
+	if len(reported) != 1 || reported[0] != reflect.TypeOf(userType{}) {
+		t.Fatalf("handler got %v, want [userType]", reported)
+	}
+}
+
+func TestValidating_MigratedEntry_FailMode(t *testing.T) {
+	prev := registry.New(defaultCfg())
+	if err := prev.Register(reflect.TypeOf(userType{}), "legacy"); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+
+	var reported []error
+	b := builder.NewValidating(builder.New(), dottedName,
+		builder.WithMigrationMode(builder.MigrationFail),
+		builder.WithMigrationErrorHandler(func(_ reflect.Type, err error) { reported = append(reported, err) }),
+	)
+
+	if reg := b.BuildRegistry(defaultCfg(), prev, nil); reg != nil {
+		t.Fatalf("BuildRegistry = %T, want nil", reg)
+	}
+	if len(reported) != 1 || !errors.Is(reported[0], builder.ErrInvalidName) {
+		t.Fatalf("handler got %v, want one ErrInvalidName", reported)
+	}
+}
+
+func TestValidating_RebuildDoesNotStackWrappers(t *testing.T) {
+	b := builder.NewValidating(builder.New(), dottedName)
+	reg1 := b.BuildRegistry(defaultCfg(), nil, nil)
+	if err := reg1.Register(reflect.TypeOf(userType{}), "domain.user"); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+
+	reg2 := b.BuildRegistry(defaultCfg(), reg1, nil)
+	if got, ok := reg2.Lookup(reflect.TypeOf(userType{})); !ok || got != "domain.user" {
+		t.Fatalf("rebuilt registry lost entry: got (%q,%v)", got, ok)
+	}
+	if err := reg2.Register(reflect.TypeOf(hotType{}), "bad"); !errors.Is(err, builder.ErrInvalidName) {
+		t.Fatalf("rebuilt registry must still validate, got %v", err)
+	}
+}
+
+func TestValidating_OptionalInterfaces(t *testing.T) {
+	b := builder.NewValidating(builder.New(), dottedName)
+	reg := b.BuildRegistry(defaultCfg(), nil, nil)
+	ut, ht := reflect.TypeOf(userType{}), reflect.TypeOf(hotType{})
+
+	disp, ok := reg.(apis.DisplayRegistry)
+	if !ok {
+		t.Fatal("validating registry hides apis.DisplayRegistry")
+	}
+	if err := disp.RegisterDisplay(ut, "nodots", "User"); !errors.Is(err, builder.ErrInvalidName) {
+		t.Fatalf("RegisterDisplay(nodots): want ErrInvalidName, got %v", err)
+	}
+	if err := disp.RegisterDisplay(ut, "domain.user", "User"); err != nil {
+		t.Fatalf("RegisterDisplay: %v", err)
+	}
+	if got, _ := disp.LookupDisplay(ut); got != "User" {
+		t.Fatalf("LookupDisplay = %q, want User", got)
+	}
+
+	meta := reg.(apis.MetadataRegistry)
+	if err := meta.RegisterDescribed(ht, apis.Description{Name: "bad"}); !errors.Is(err, builder.ErrInvalidName) {
+		t.Fatalf("RegisterDescribed(bad): want ErrInvalidName, got %v", err)
+	}
+
+	// Lazy names are validated when they are computed.
+	lazy := reg.(apis.LazyRegistry)
+	if err := lazy.RegisterLazy(ht, func() (string, error) { return "bad", nil }); err != nil {
+		t.Fatalf("RegisterLazy: %v", err)
+	}
+	if _, ok := reg.Lookup(ht); ok {
+		t.Fatal("invalid lazy name must not resolve")
+	}
+
+	if n := reg.(apis.PrefixQuerier).CountByPrefix("domain."); n != 1 {
+		t.Fatalf("CountByPrefix = %d, want 1", n)
+	}
+	if got, ok := reg.(apis.Snapshotter).Snapshot().Lookup(ut); !ok || got != "domain.user" {
+		t.Fatalf("Snapshot().Lookup = (%q,%v)", got, ok)
+	}
+	if !reg.(apis.Unregisterer).Unregister(ut) {
+		t.Fatal("Unregister reported false")
+	}
+}
@@ -0,0 +1,67 @@
+/*
+   Copyright 2025 The DIRPX Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rfx
+
+import (
+	uref "dirpx.dev/rfx/utils/reflect"
+)
+
+// CanonicalName maps an incoming name, e.g. one received over a wire protocol
+// from an older peer, to the canonical name this process resolves to, and
+// reports whether raw is known at all. Lookups happen in this order:
+//
+//  1. raw is a name in the global rfx reg: it is already canonical.
+//  2. raw is the reflect-style name of a registered type, either the full
+//     "pkgpath.Type" or the "pkg.Type" form: its registered name.
+//  3. raw is a key of Config.TypeAliases: the alias it maps to.
+//  4. raw is a value of Config.TypeAliases: it is already canonical.
+//
+// The registry is consulted before aliases because registration wins over
+// the reflect strategy during resolution as well. CanonicalName scans the
+// registry and is meant for decoding paths, not per-call hot paths.
+// The configuration can be overridden per goroutine with WithConfigScope.
+func CanonicalName(raw string) (string, bool) {
+	if raw == "" {
+		return "", false
+	}
+	s := load()
+
+	entries := s.reg.Entries()
+	for _, e := range entries {
+		if e.Name == raw {
+			return raw, true
+		}
+	}
+	for _, e := range entries {
+		if e.Type == nil || e.Type.PkgPath() == "" {
+			continue
+		}
+		if uref.FullName(e.Type) == raw ||
+			uref.PackageBase(e.Type.PkgPath(), s.cfg.TrimModuleVersion)+"."+uref.StripTypeParams(e.Type.Name()) == raw {
+			return e.Name, true
+		}
+	}
+	if alias, ok := s.cfg.TypeAliases[raw]; ok {
+		return alias, true
+	}
+	for _, alias := range s.cfg.TypeAliases {
+		if alias == raw {
+			return raw, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,42 @@
+package rfx
+
+import (
+	"reflect"
+	"testing"
+
+	"dirpx.dev/rfx/builder"
+	"dirpx.dev/rfx/config"
+	uref "dirpx.dev/rfx/utils/reflect"
+)
+
+func TestCanonicalName(t *testing.T) {
+	defer Capture().Restore()
+	cfg := config.NewConfig(config.WithTypeAliases(map[string]string{
+		"legacy.order": "sales.order",
+	}))
+	resetWithBuilder(t, builder.New(), cfg, nil)
+	Registry().Reset()
+	if err := RegisterType(reflect.TypeOf(derivedA{}), "canon.a"); err != nil {
+		t.Fatalf("RegisterType: %v", err)
+	}
+
+	tests := []struct {
+		raw    string
+		want   string
+		wantOK bool
+	}{
+		{"canon.a", "canon.a", true},
+		{"rfx.derivedA", "canon.a", true},
+		{uref.FullName(reflect.TypeOf(derivedA{})), "canon.a", true},
+		{"legacy.order", "sales.order", true},
+		{"sales.order", "sales.order", true},
+		{"rfx.derivedB", "", false},
+		{"", "", false},
+	}
+	for _, tt := range tests {
+		got, ok := CanonicalName(tt.raw)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("CanonicalName(%q) = (%q, %v), want (%q, %v)", tt.raw, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
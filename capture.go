@@ -0,0 +1,49 @@
+/*
+   Copyright 2025 The DIRPX Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rfx
+
+// Restorer is an opaque handle to a captured global rfx state.
+// The zero value restores nothing.
+type Restorer struct {
+	s *state
+}
+
+// Capture captures the current global rfx state so it can be republished
+// later with Restore. Typical use in tests:
+//
+//	defer rfx.Capture().Restore()
+//
+// The snapshot holds the same cfg, ext, reg, res, bld and pin flags, by
+// reference: mutations made through the captured registry itself
+// (e.g. RegisterType) are not undone, but any swapped-in components are.
+func Capture() Restorer {
+	return Restorer{s: st.Load()}
+}
+
+// Restore republishes the exact captured state, discarding every state
+// published since Capture.
+func (r Restorer) Restore() {
+	if r.s == nil {
+		return
+	}
+	buildMu.Lock()
+	defer buildMu.Unlock()
+	if frozenGuard() {
+		return
+	}
+	st.Store(r.s)
+}
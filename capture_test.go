@@ -0,0 +1,41 @@
+package rfx
+
+import (
+	"testing"
+
+	"dirpx.dev/rfx/apis"
+	"dirpx.dev/rfx/builder"
+	"dirpx.dev/rfx/config"
+)
+
+func TestCapture_RestoresExactState(t *testing.T) {
+	resetWithBuilder(t, builder.New(), config.DefaultConfig(), nil)
+	before := st.Load()
+
+	func() {
+		defer Capture().Restore()
+
+		SetConfig(apis.Config{MaxUnwrap: 2})
+		SetBuilder(&mockBuilder{})
+		PinRegistry()
+		SetExt("scoped")
+		if st.Load() == before {
+			t.Fatal("state should have changed inside the scope")
+		}
+	}()
+
+	if st.Load() != before {
+		t.Fatal("Restore did not republish the captured state")
+	}
+	if IsRegistryPinned() || Config().MaxUnwrap != before.cfg.MaxUnwrap {
+		t.Fatal("restored state does not match the captured one")
+	}
+}
+
+func TestRestorer_ZeroValueIsNoop(t *testing.T) {
+	before := st.Load()
+	Restorer{}.Restore()
+	if st.Load() != before {
+		t.Fatal("zero Restorer must not change the state")
+	}
+}
@@ -0,0 +1,96 @@
+/*
+   Copyright 2025 The DIRPX Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rfx
+
+import (
+	"reflect"
+	"strconv"
+
+	uref "dirpx.dev/rfx/utils/reflect"
+)
+
+// EntityElem resolves the name of the innermost element of a container value
+// (pointer, slice, array, chan, or map according to MapPreferElem) using the
+// global rfx res. Non-container values, and pointers to them, resolve
+// exactly like Entity, so a Namer on the value applies. Elements reached
+// through a slice, array, chan or map are resolved by type, which skips
+// Namer.
+//
+// Unwrapping is bounded by Config.MaxUnwrap: if the element is nested deeper
+// than MaxUnwrap layers, no named element is reached and "" is returned.
+func EntityElem(v any) string {
+	if v == nil {
+		return ""
+	}
+	s := load()
+	t := reflect.TypeOf(v)
+	e := t
+	for e.Kind() == reflect.Ptr {
+		e = e.Elem()
+	}
+	if !uref.IsContainerKind(e.Kind()) {
+		return s.res.Resolve(v, s.cfg)
+	}
+	return s.res.ResolveType(t, s.cfg)
+}
+
+// EntityCollection resolves the element name of a container value like
+// EntityElem and wraps it according to the outermost container kind:
+// "[]name" for slices, "[N]name" for arrays, "chan name" for channels and
+// "map[key]name" for maps (key and element are resolved separately).
+// Leading pointers are dereferenced first. Non-container values resolve
+// exactly like Entity. If the element name is empty, "" is returned.
+//
+// Element resolution is bounded by Config.MaxUnwrap like EntityElem.
+func EntityCollection(v any) string {
+	if v == nil {
+		return ""
+	}
+	s := load()
+	t := reflect.TypeOf(v)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	var prefix string
+	switch t.Kind() {
+	case reflect.Slice:
+		prefix = "[]"
+	case reflect.Array:
+		prefix = "[" + strconv.Itoa(t.Len()) + "]"
+	case reflect.Chan:
+		prefix = "chan "
+	case reflect.Map:
+		key := s.res.ResolveType(t.Key(), s.cfg)
+		if key == "" {
+			key = t.Key().String()
+		}
+		elem := s.res.ResolveType(t.Elem(), s.cfg)
+		if elem == "" {
+			return ""
+		}
+		return "map[" + key + "]" + elem
+	default:
+		return s.res.Resolve(v, s.cfg)
+	}
+
+	elem := s.res.ResolveType(t.Elem(), s.cfg)
+	if elem == "" {
+		return ""
+	}
+	return prefix + elem
+}
@@ -0,0 +1,68 @@
+package rfx
+
+import (
+	"reflect"
+	"testing"
+
+	"dirpx.dev/rfx/builder"
+	"dirpx.dev/rfx/config"
+)
+
+type collOrder struct{}
+type collKey struct{}
+type collNamed struct{}
+
+func (collNamed) EntityName() string { return "coll.named" }
+
+func TestEntityElem_And_EntityCollection(t *testing.T) {
+	resetWithBuilder(t, builder.New(), config.DefaultConfig(), nil)
+	Registry().Reset()
+	if err := RegisterType(reflect.TypeOf(collOrder{}), "order"); err != nil {
+		t.Fatalf("RegisterType: %v", err)
+	}
+	if err := RegisterType(reflect.TypeOf(collKey{}), "key"); err != nil {
+		t.Fatalf("RegisterType: %v", err)
+	}
+
+	cases := []struct {
+		name     string
+		val      any
+		wantElem string
+		wantColl string
+	}{
+		{"slice", []collOrder{}, "order", "[]order"},
+		{"ptr to slice", &[]collOrder{}, "order", "[]order"},
+		{"array", [2]collOrder{}, "order", "[2]order"},
+		{"chan", make(chan collOrder), "order", "chan order"},
+		{"map", map[collKey]collOrder{}, "order", "map[key]order"},
+		{"map builtin key", map[string]collOrder{}, "order", "map[string]order"},
+		{"plain", collOrder{}, "order", "order"},
+		{"ptr to namer", &collNamed{}, "coll.named", "coll.named"},
+		{"nil", nil, "", ""},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := EntityElem(tc.val); got != tc.wantElem {
+				t.Fatalf("EntityElem: got %q, want %q", got, tc.wantElem)
+			}
+			if got := EntityCollection(tc.val); got != tc.wantColl {
+				t.Fatalf("EntityCollection: got %q, want %q", got, tc.wantColl)
+			}
+		})
+	}
+}
+
+func TestEntityElem_MaxUnwrap(t *testing.T) {
+	cfg := config.NewConfig(config.WithMaxUnwrap(1))
+	resetWithBuilder(t, builder.New(), cfg, nil)
+	Registry().Reset()
+	defer resetWithBuilder(t, builder.New(), config.DefaultConfig(), nil)
+
+	// [][]collOrder needs two unwraps to reach collOrder.
+	if got := EntityElem([][]collOrder{}); got != "" {
+		t.Fatalf("EntityElem beyond MaxUnwrap: got %q, want empty", got)
+	}
+	if got := EntityCollection([]collOrder{}); got != "[]rfx.collOrder" {
+		t.Fatalf("EntityCollection: got %q, want %q", got, "[]rfx.collOrder")
+	}
+}
@@ -30,6 +30,9 @@ const (
 	// DefaultMapPreferElem represents the default for MapPreferElem.
 	// When true, map value types are preferred when searching for named inner types.
 	DefaultMapPreferElem = true
+	// DefaultTrimModuleVersion represents the default for TrimModuleVersion.
+	// When true, "/vN" module suffixes do not become the package name.
+	DefaultTrimModuleVersion = true
 )
 
 // NewConfig constructs an apis.Config from the given options.
@@ -48,9 +51,10 @@ func NewConfig(opts ...Option) apis.Config {
 // DefaultConfig is the default configuration used when none is provided.
 func DefaultConfig() apis.Config {
 	return apis.Config{
-		IncludeBuiltins: DefaultIncludeBuiltins,
-		MaxUnwrap:       DefaultMaxUnwrap,
-		MapPreferElem:   DefaultMapPreferElem,
+		IncludeBuiltins:   DefaultIncludeBuiltins,
+		MaxUnwrap:         DefaultMaxUnwrap,
+		MapPreferElem:     DefaultMapPreferElem,
+		TrimModuleVersion: DefaultTrimModuleVersion,
 	}
 }
 
@@ -76,6 +80,168 @@ func WithMaxUnwrap(max int) Option {
 	}
 }
 
+// WithNormalizeOutermost sets the NormalizeOutermost option.
+func WithNormalizeOutermost(outermost bool) Option {
+	return func(c *apis.Config) {
+		c.NormalizeOutermost = outermost
+	}
+}
+
+// WithUnwrapFinalNamed sets the UnwrapFinalNamed option.
+func WithUnwrapFinalNamed(enabled bool) Option {
+	return func(c *apis.Config) {
+		c.UnwrapFinalNamed = enabled
+	}
+}
+
+// WithNameStyle sets the NameStyle option.
+func WithNameStyle(style apis.NameStyle) Option {
+	return func(c *apis.Config) {
+		c.NameStyle = style
+	}
+}
+
+// WithRejectUnsafeKinds selects apis.UnsafeKindReject, or drops it back to
+// apis.UnsafeKindResolve when reject is false. It sets UnsafeKinds rather
+// than the deprecated RejectUnsafeKinds field.
+//
+// Deprecated: use WithUnsafeKinds(apis.UnsafeKindReject).
+func WithRejectUnsafeKinds(reject bool) Option {
+	return func(c *apis.Config) {
+		c.RejectUnsafeKinds = false
+		switch {
+		case reject:
+			c.UnsafeKinds = apis.UnsafeKindReject
+		case c.UnsafeKinds == apis.UnsafeKindReject:
+			c.UnsafeKinds = apis.UnsafeKindResolve
+		}
+	}
+}
+
+// WithUnsafeKinds sets the UnsafeKinds option.
+func WithUnsafeKinds(policy apis.UnsafeKindPolicy) Option {
+	return func(c *apis.Config) {
+		c.UnsafeKinds = policy
+	}
+}
+
+// WithTrimModuleVersion sets the TrimModuleVersion option.
+func WithTrimModuleVersion(trim bool) Option {
+	return func(c *apis.Config) {
+		c.TrimModuleVersion = trim
+	}
+}
+
+// WithRegistryOverridesNamer sets the RegistryOverridesNamer option.
+func WithRegistryOverridesNamer(override bool) Option {
+	return func(c *apis.Config) {
+		c.RegistryOverridesNamer = override
+	}
+}
+
+// WithOmitPackage sets the OmitPackage option.
+func WithOmitPackage(omit bool) Option {
+	return func(c *apis.Config) {
+		c.OmitPackage = omit
+	}
+}
+
+// WithFriendlyByteSlices sets the FriendlyByteSlices option.
+func WithFriendlyByteSlices(friendly bool) Option {
+	return func(c *apis.Config) {
+		c.FriendlyByteSlices = friendly
+	}
+}
+
+// WithPkgPathHashSuffix sets the PkgPathHashSuffix option.
+func WithPkgPathHashSuffix(suffix bool) Option {
+	return func(c *apis.Config) {
+		c.PkgPathHashSuffix = suffix
+	}
+}
+
+// WithGlobalPrefix sets the GlobalPrefix option.
+func WithGlobalPrefix(prefix string) Option {
+	return func(c *apis.Config) {
+		c.GlobalPrefix = prefix
+	}
+}
+
+// WithKeepContainerMarkers sets the KeepContainerMarkers option.
+func WithKeepContainerMarkers(keep bool) Option {
+	return func(c *apis.Config) {
+		c.KeepContainerMarkers = keep
+	}
+}
+
+// WithKeepArrayLen sets the KeepArrayLen option.
+func WithKeepArrayLen(keep bool) Option {
+	return func(c *apis.Config) {
+		c.KeepArrayLen = keep
+	}
+}
+
+// WithDistinguishArrays sets the DistinguishArrays option.
+func WithDistinguishArrays(distinguish bool) Option {
+	return func(c *apis.Config) {
+		c.DistinguishArrays = distinguish
+	}
+}
+
+// WithDistinguishPointers sets the DistinguishPointers option.
+func WithDistinguishPointers(distinguish bool) Option {
+	return func(c *apis.Config) {
+		c.DistinguishPointers = distinguish
+	}
+}
+
+// WithPointerDepthInName sets the PointerDepthInName option.
+func WithPointerDepthInName(depth bool) Option {
+	return func(c *apis.Config) {
+		c.PointerDepthInName = depth
+	}
+}
+
+// WithKeepPointerDepth sets the KeepPointerDepth option.
+func WithKeepPointerDepth(keep bool) Option {
+	return func(c *apis.Config) {
+		c.KeepPointerDepth = keep
+	}
+}
+
+// WithMaxNameLen sets the MaxNameLen option.
+// A negative value resets to 0 (unlimited).
+func WithMaxNameLen(max int) Option {
+	return func(c *apis.Config) {
+		if max < 0 {
+			max = 0
+		}
+		c.MaxNameLen = max
+	}
+}
+
+// WithDisableReflectFallback sets the DisableReflectFallback option.
+func WithDisableReflectFallback(disable bool) Option {
+	return func(c *apis.Config) {
+		c.DisableReflectFallback = disable
+	}
+}
+
+// WithTypeAliases sets the TypeAliases option.
+// The map is copied, so later mutations by the caller have no effect.
+func WithTypeAliases(aliases map[string]string) Option {
+	return func(c *apis.Config) {
+		if len(aliases) == 0 {
+			c.TypeAliases = nil
+			return
+		}
+		c.TypeAliases = make(map[string]string, len(aliases))
+		for k, v := range aliases {
+			c.TypeAliases[k] = v
+		}
+	}
+}
+
 // WithMapPreferElem sets the MapPreferElem option.
 func WithMapPreferElem(prefer bool) Option {
 	return func(c *apis.Config) {
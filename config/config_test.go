@@ -17,8 +17,10 @@
 package config_test
 
 import (
+	"reflect"
 	"testing"
 
+	"dirpx.dev/rfx/apis"
 	"dirpx.dev/rfx/config"
 )
 
@@ -34,12 +36,15 @@ func TestDefaultConfigValues(t *testing.T) {
 	if got.MapPreferElem != config.DefaultMapPreferElem {
 		t.Fatalf("MapPreferElem = %v, want %v", got.MapPreferElem, config.DefaultMapPreferElem)
 	}
+	if got.TrimModuleVersion != config.DefaultTrimModuleVersion {
+		t.Fatalf("TrimModuleVersion = %v, want %v", got.TrimModuleVersion, config.DefaultTrimModuleVersion)
+	}
 }
 
 func TestNewConfig_NoOptions_EqualsDefault(t *testing.T) {
 	def := config.DefaultConfig()
 	got := config.NewConfig()
-	if got != def {
+	if !reflect.DeepEqual(got, def) {
 		t.Fatalf("NewConfig() = %+v, want default %+v", got, def)
 	}
 }
@@ -104,9 +109,152 @@ func TestOptionsOrder_LastWins(t *testing.T) {
 }
 
 func TestNewConfig_Guardrails_MaxUnwrapZeroAllowed(t *testing.T) {
-	// The constructor only resets negative values. Zero is allowed by design.
+	// The constructor only resets negative values. Zero is allowed by design
+	// and means "no unwrapping": only exact named types resolve.
 	c := config.NewConfig(config.WithMaxUnwrap(0))
 	if c.MaxUnwrap != 0 {
 		t.Fatalf("MaxUnwrap = %d, want 0 (zero is allowed)", c.MaxUnwrap)
 	}
 }
+
+func TestWithTypeAliases_Copies(t *testing.T) {
+	src := map[string]string{"time.Time": "timestamp"}
+	c := config.NewConfig(config.WithTypeAliases(src))
+	src["time.Time"] = "mutated"
+
+	if got := c.TypeAliases["time.Time"]; got != "timestamp" {
+		t.Fatalf("TypeAliases[time.Time] = %q, want %q", got, "timestamp")
+	}
+
+	c2 := config.NewConfig(config.WithTypeAliases(nil))
+	if c2.TypeAliases != nil {
+		t.Fatalf("TypeAliases = %v, want nil", c2.TypeAliases)
+	}
+}
+
+func TestWithTrimModuleVersion(t *testing.T) {
+	if c := config.NewConfig(config.WithTrimModuleVersion(false)); c.TrimModuleVersion {
+		t.Fatalf("TrimModuleVersion = true, want false")
+	}
+}
+
+func TestWithUnsafeKinds(t *testing.T) {
+	if c := config.NewConfig(); c.UnsafeKinds != apis.UnsafeKindResolve {
+		t.Fatalf("UnsafeKinds default = %v, want UnsafeKindResolve", c.UnsafeKinds)
+	}
+	if c := config.NewConfig(config.WithUnsafeKinds(apis.UnsafeKindError)); c.UnsafeKinds != apis.UnsafeKindError {
+		t.Fatalf("UnsafeKinds = %v, want UnsafeKindError", c.UnsafeKinds)
+	}
+}
+
+func TestWithRejectUnsafeKinds(t *testing.T) {
+	if c := config.NewConfig(); c.RejectUnsafeKinds {
+		t.Fatalf("RejectUnsafeKinds default = true, want false")
+	}
+	c := config.NewConfig(config.WithRejectUnsafeKinds(true))
+	if c.RejectUnsafeKinds || c.UnsafeKinds != apis.UnsafeKindReject {
+		t.Fatalf("WithRejectUnsafeKinds(true): got (%v,%v), want (false,UnsafeKindReject)", c.RejectUnsafeKinds, c.UnsafeKinds)
+	}
+	c = config.NewConfig(config.WithRejectUnsafeKinds(true), config.WithRejectUnsafeKinds(false))
+	if c.UnsafeKindPolicy() != apis.UnsafeKindResolve {
+		t.Fatalf("WithRejectUnsafeKinds(false): policy = %v, want UnsafeKindResolve", c.UnsafeKindPolicy())
+	}
+	// The deprecated field still selects the reject policy.
+	if c := (apis.Config{RejectUnsafeKinds: true, UnsafeKinds: apis.UnsafeKindFixedName}); c.UnsafeKindPolicy() != apis.UnsafeKindReject {
+		t.Fatalf("RejectUnsafeKinds field: policy = %v, want UnsafeKindReject", c.UnsafeKindPolicy())
+	}
+}
+
+func TestWithMaxNameLen(t *testing.T) {
+	if c := config.NewConfig(config.WithMaxNameLen(64)); c.MaxNameLen != 64 {
+		t.Fatalf("MaxNameLen = %d, want 64", c.MaxNameLen)
+	}
+	if c := config.NewConfig(config.WithMaxNameLen(-1)); c.MaxNameLen != 0 {
+		t.Fatalf("MaxNameLen = %d, want 0", c.MaxNameLen)
+	}
+}
+
+func TestWithKeepContainerMarkers(t *testing.T) {
+	if c := config.NewConfig(config.WithKeepContainerMarkers(true)); !c.KeepContainerMarkers {
+		t.Fatal("KeepContainerMarkers = false, want true")
+	}
+}
+
+func TestWithNormalizeOutermost(t *testing.T) {
+	if c := config.NewConfig(config.WithNormalizeOutermost(true)); !c.NormalizeOutermost {
+		t.Fatal("NormalizeOutermost = false, want true")
+	}
+}
+
+func TestWithKeepArrayLen(t *testing.T) {
+	if c := config.NewConfig(config.WithKeepArrayLen(true)); !c.KeepArrayLen {
+		t.Fatal("KeepArrayLen = false, want true")
+	}
+}
+
+func TestWithDistinguishArrays(t *testing.T) {
+	if c := config.NewConfig(config.WithDistinguishArrays(true)); !c.DistinguishArrays {
+		t.Fatal("DistinguishArrays = false, want true")
+	}
+}
+
+func TestWithDisableReflectFallback(t *testing.T) {
+	if c := config.NewConfig(config.WithDisableReflectFallback(true)); !c.DisableReflectFallback {
+		t.Fatal("DisableReflectFallback = false, want true")
+	}
+}
+
+func TestWithRegistryOverridesNamer(t *testing.T) {
+	if c := config.NewConfig(config.WithRegistryOverridesNamer(true)); !c.RegistryOverridesNamer {
+		t.Fatal("RegistryOverridesNamer = false, want true")
+	}
+}
+
+func TestWithOmitPackage(t *testing.T) {
+	if c := config.NewConfig(config.WithOmitPackage(true)); !c.OmitPackage {
+		t.Fatal("OmitPackage = false, want true")
+	}
+}
+
+func TestWithFriendlyByteSlices(t *testing.T) {
+	if c := config.NewConfig(config.WithFriendlyByteSlices(true)); !c.FriendlyByteSlices {
+		t.Fatal("FriendlyByteSlices = false, want true")
+	}
+}
+
+func TestWithPkgPathHashSuffix(t *testing.T) {
+	if c := config.NewConfig(config.WithPkgPathHashSuffix(true)); !c.PkgPathHashSuffix {
+		t.Fatal("PkgPathHashSuffix = false, want true")
+	}
+}
+
+func TestWithUnwrapFinalNamed(t *testing.T) {
+	if c := config.NewConfig(config.WithUnwrapFinalNamed(true)); !c.UnwrapFinalNamed {
+		t.Fatal("UnwrapFinalNamed = false, want true")
+	}
+}
+
+func TestWithNameStyle(t *testing.T) {
+	if c := config.NewConfig(config.WithNameStyle(apis.NameStyleKebabCase)); c.NameStyle != apis.NameStyleKebabCase {
+		t.Fatalf("NameStyle = %v, want NameStyleKebabCase", c.NameStyle)
+	}
+}
+
+func TestWithGlobalPrefix(t *testing.T) {
+	if c := config.NewConfig(config.WithGlobalPrefix("tenant-a")); c.GlobalPrefix != "tenant-a" {
+		t.Fatalf("GlobalPrefix = %q, want tenant-a", c.GlobalPrefix)
+	}
+}
+
+func TestWithPointerOptions(t *testing.T) {
+	c := config.NewConfig(config.WithDistinguishPointers(true), config.WithPointerDepthInName(true))
+	if !c.DistinguishPointers || !c.PointerDepthInName {
+		t.Fatalf("pointer options not applied: %+v", c)
+	}
+}
+
+func TestWithKeepPointerDepth(t *testing.T) {
+	if c := config.NewConfig(config.WithKeepPointerDepth(true)); !c.KeepPointerDepth {
+		t.Fatal("KeepPointerDepth = false, want true")
+	}
+}
@@ -0,0 +1,65 @@
+/*
+   Copyright 2025 The DIRPX Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package config
+
+import (
+	"reflect"
+
+	"dirpx.dev/rfx/apis"
+)
+
+// FieldChange describes a single apis.Config field that differs between two
+// configurations.
+type FieldChange struct {
+	// Field is the Go name of the field, e.g. "MaxUnwrap".
+	Field string
+	// Old is the field value in the old configuration.
+	Old any
+	// New is the field value in the new configuration.
+	New any
+}
+
+// Diff returns the fields that differ between old and new, in declaration
+// order. Fields are enumerated by reflection, so knobs added to apis.Config
+// later are covered without changes here. Fields compare with the semantics
+// of apis.Config.Equal, so maps compare by content and a nil map equals an
+// empty one. A nil result means the configurations are equal.
+func Diff(old, new apis.Config) []FieldChange {
+	ov, nv := reflect.ValueOf(old), reflect.ValueOf(new)
+	t := ov.Type()
+
+	var out []FieldChange
+	for i := 0; i < t.NumField(); i++ {
+		if !t.Field(i).IsExported() {
+			continue
+		}
+		if !fieldEqual(ov, nv, i) {
+			o, n := ov.Field(i).Interface(), nv.Field(i).Interface()
+			out = append(out, FieldChange{Field: t.Field(i).Name, Old: o, New: n})
+		}
+	}
+	return out
+}
+
+// fieldEqual compares field i of ov and nv with apis.Config.Equal, by
+// copying it into otherwise zero configurations.
+func fieldEqual(ov, nv reflect.Value, i int) bool {
+	var a, b apis.Config
+	reflect.ValueOf(&a).Elem().Field(i).Set(ov.Field(i))
+	reflect.ValueOf(&b).Elem().Field(i).Set(nv.Field(i))
+	return a.Equal(b)
+}
@@ -0,0 +1,57 @@
+/*
+   Copyright 2025 The DIRPX Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package config_test
+
+import (
+	"reflect"
+	"testing"
+
+	"dirpx.dev/rfx/config"
+)
+
+func TestDiff(t *testing.T) {
+	old := config.DefaultConfig()
+	if d := config.Diff(old, config.DefaultConfig()); d != nil {
+		t.Fatalf("Diff(equal) = %+v, want nil", d)
+	}
+
+	next := config.NewConfig(
+		config.WithMaxUnwrap(3),
+		config.WithIncludeBuiltins(false),
+		config.WithTypeAliases(map[string]string{"time.Time": "timestamp"}),
+	)
+	want := []config.FieldChange{
+		{Field: "IncludeBuiltins", Old: true, New: false},
+		{Field: "MaxUnwrap", Old: config.DefaultMaxUnwrap, New: 3},
+		{Field: "TypeAliases", Old: map[string]string(nil), New: map[string]string{"time.Time": "timestamp"}},
+	}
+	if got := config.Diff(old, next); !reflect.DeepEqual(got, want) {
+		t.Fatalf("Diff = %+v, want %+v", got, want)
+	}
+}
+
+func TestDiff_MatchesEqual(t *testing.T) {
+	a := config.DefaultConfig()
+	b := config.DefaultConfig()
+	b.TypeAliases = map[string]string{}
+	if !a.Equal(b) {
+		t.Fatal("nil and empty TypeAliases should be Equal")
+	}
+	if d := config.Diff(a, b); d != nil {
+		t.Fatalf("Diff(nil vs empty TypeAliases) = %+v, want nil", d)
+	}
+}
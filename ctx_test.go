@@ -0,0 +1,42 @@
+package rfx
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"dirpx.dev/rfx/apis"
+	"dirpx.dev/rfx/builder"
+	"dirpx.dev/rfx/config"
+	"dirpx.dev/rfx/resolver"
+	"dirpx.dev/rfx/strategy"
+)
+
+type ctxTenantKey struct{}
+
+// ctxTenantStrategy resolves every value to "<tenant>.entity" from ctx.
+type ctxTenantStrategy struct{}
+
+func (ctxTenantStrategy) TryResolve(any, apis.Config) (string, bool)              { return "", false }
+func (ctxTenantStrategy) TryResolveType(reflect.Type, apis.Config) (string, bool) { return "", false }
+func (ctxTenantStrategy) TryResolveCtx(ctx context.Context, _ any, _ apis.Config) (string, bool) {
+	tenant, ok := ctx.Value(ctxTenantKey{}).(string)
+	return tenant + ".entity", ok
+}
+
+func TestEntityCtx(t *testing.T) {
+	defer Capture().Restore()
+	resetWithBuilder(t, builder.New(), config.DefaultConfig(), nil)
+	SetResolver(resolver.New(ctxTenantStrategy{}, strategy.NewReflectStrategy()))
+
+	ctx := context.WithValue(context.Background(), ctxTenantKey{}, "acme")
+	if got := EntityCtx(ctx, derivedA{}); got != "acme.entity" {
+		t.Fatalf("EntityCtx(tenant) = %q, want acme.entity", got)
+	}
+	if got := EntityCtx(context.Background(), derivedA{}); got != "rfx.derivedA" {
+		t.Fatalf("EntityCtx(no tenant) = %q, want rfx.derivedA", got)
+	}
+	if got := Entity(derivedA{}); got != "rfx.derivedA" {
+		t.Fatalf("Entity = %q, want rfx.derivedA", got)
+	}
+}
@@ -0,0 +1,92 @@
+/*
+   Copyright 2025 The DIRPX Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rfx
+
+import (
+	"errors"
+	"reflect"
+
+	"dirpx.dev/rfx/apis"
+)
+
+// ErrNoMetadataRegistry is returned by RegisterDescribed when the global rfx
+// reg does not implement apis.MetadataRegistry.
+var ErrNoMetadataRegistry = errors.New("rfx: registry does not support descriptions")
+
+// RegisterDescribed registers T in the global rfx reg under d.Name and
+// records d as its metadata, so DescriptionOf and Describe report it even
+// though T does not implement apis.Describer. It is meant for generated code.
+// After Freeze it returns ErrFrozen (or panics, see FreezePanics).
+func RegisterDescribed[T any](d apis.Description) error {
+	if frozen.Load() {
+		return frozenErr()
+	}
+	m, ok := st.Load().reg.(apis.MetadataRegistry)
+	if !ok {
+		return ErrNoMetadataRegistry
+	}
+	return m.RegisterDescribed(reflect.TypeFor[T](), d)
+}
+
+// DescriptionOf returns the metadata of v: from apis.Describer if v
+// implements it, otherwise the description registered for its type with
+// RegisterDescribed. v may also be a reflect.Type, which is looked up in the
+// registry only. ok is false if neither source knows v.
+func DescriptionOf(v any) (d apis.Description, ok bool) {
+	if ds, isDescriber := v.(apis.Describer); isDescriber {
+		return apis.Description{
+			Name:        ds.EntityName(),
+			Version:     ds.EntityVersion(),
+			Category:    ds.EntityCategory(),
+			Description: ds.EntityDescription(),
+		}, true
+	}
+	return registeredDescription(v)
+}
+
+// registeredDescription looks up the description registered for v's type.
+func registeredDescription(v any) (apis.Description, bool) {
+	t, isType := v.(reflect.Type)
+	if !isType {
+		t = reflect.TypeOf(v)
+	}
+	m, ok := load().reg.(apis.MetadataRegistry)
+	if !ok || t == nil {
+		return apis.Description{}, false
+	}
+	return m.LookupDescription(t)
+}
+
+// Describe returns the human-readable description of v in the given locale.
+// It prefers apis.LocalizedDescriber and falls back to
+// apis.Describer.EntityDescription when v is not localized or has no text for
+// locale, and then to the description registered with RegisterDescribed.
+// Values known to none of these describe as "".
+//
+// rfx does not negotiate locales: pass the locale the caller wants displayed.
+func Describe(v any, locale string) string {
+	if l, ok := v.(apis.LocalizedDescriber); ok {
+		if s := l.EntityDescriptionFor(locale); s != "" {
+			return s
+		}
+	}
+	if d, ok := v.(apis.Describer); ok {
+		return d.EntityDescription()
+	}
+	d, _ := registeredDescription(v)
+	return d.Description
+}
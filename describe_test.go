@@ -0,0 +1,87 @@
+package rfx
+
+import (
+	"reflect"
+	"testing"
+
+	"dirpx.dev/rfx/apis"
+	"dirpx.dev/rfx/builder"
+	"dirpx.dev/rfx/config"
+)
+
+// localizedEntity implements both apis.Describer and apis.LocalizedDescriber.
+type localizedEntity struct{ fullEntity }
+
+func (localizedEntity) EntityDescriptionFor(locale string) string {
+	switch locale {
+	case "de":
+		return "ein Cache-Eintrag"
+	case "fr":
+		return "une entrée de cache"
+	}
+	return ""
+}
+
+func TestDescribe(t *testing.T) {
+	tests := []struct {
+		name   string
+		v      any
+		locale string
+		want   string
+	}{
+		{"localized", localizedEntity{}, "de", "ein Cache-Eintrag"},
+		{"localized other", localizedEntity{}, "fr", "une entrée de cache"},
+		{"unknown locale falls back", localizedEntity{}, "ja", "a cache entry"},
+		{"plain describer", fullEntity{}, "de", "a cache entry"},
+		{"namer only", namerOnly{}, "de", ""},
+		{"nil", nil, "de", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Describe(tt.v, tt.locale); got != tt.want {
+				t.Fatalf("Describe(%T, %q) = %q, want %q", tt.v, tt.locale, got, tt.want)
+			}
+		})
+	}
+}
+
+// generatedEntity stands in for a code-generated type without methods.
+type generatedEntity struct{}
+
+func TestRegisterDescribed(t *testing.T) {
+	defer Capture().Restore()
+	resetWithBuilder(t, builder.New(), config.DefaultConfig(), nil)
+	Registry().Reset()
+
+	want := apis.Description{Name: "gen.entity", Version: "v3", Category: "gen", Description: "a generated entity"}
+	if err := RegisterDescribed[generatedEntity](want); err != nil {
+		t.Fatalf("RegisterDescribed: %v", err)
+	}
+
+	// The metadata survives a rebuild of the registry.
+	SetConfig(config.NewConfig(config.WithIncludeBuiltins(true)))
+
+	if got := Entity(generatedEntity{}); got != want.Name {
+		t.Fatalf("Entity = %q, want %q", got, want.Name)
+	}
+	for _, v := range []any{generatedEntity{}, &generatedEntity{}, reflect.TypeOf(generatedEntity{})} {
+		if got, ok := DescriptionOf(v); !ok || got != want {
+			t.Fatalf("DescriptionOf(%T) = (%+v, %v), want (%+v, true)", v, got, ok, want)
+		}
+	}
+	if got := Describe(generatedEntity{}, "de"); got != want.Description {
+		t.Fatalf("Describe = %q, want %q", got, want.Description)
+	}
+
+	// Describers in code report their own metadata.
+	if got, ok := DescriptionOf(fullEntity{}); !ok || got.Version != "v2" || got.Description != "a cache entry" {
+		t.Fatalf("DescriptionOf(fullEntity) = (%+v, %v)", got, ok)
+	}
+	if _, ok := DescriptionOf(namerOnly{}); ok {
+		t.Fatal("DescriptionOf(namerOnly) should report false")
+	}
+
+	if err := RegisterDescribed[generatedEntity](apis.Description{Name: "other"}); err == nil {
+		t.Fatal("RegisterDescribed with a conflicting name should fail")
+	}
+}
@@ -147,6 +147,12 @@
 //   - When you call SetResolver(res), that Resolver is pinned and will
 //     not be rebuilt automatically until UnpinResolver().
 //
+//   - When you call PinConfig(), the current Config is kept: SetConfig()
+//     becomes a no-op (TrySetConfig returns ErrConfigPinned) and SetAll()
+//     ignores its cfg argument until UnpinConfig().
+//
+//   - PinAll() and UnpinAll() flip all three pins at once.
+//
 // Pinning is there for advanced scenarios where you want full control
 // over one layer while still letting other layers evolve. For example,
 // you may lock a custom Resolver for audit/telemetry formatting but still
@@ -160,6 +166,17 @@
 // on each rebuild, so out-of-tree builders can inject custom naming rules
 // or policy logic without hacking the rfx core.
 //
+// # Initial configuration
+//
+// The package init publishes a first snapshot built from
+// config.DefaultConfig() and builder.New(). There is deliberately no
+// exported variable to tweak that config: Go runs rfx's init before the init
+// of any package importing it, so such a variable could never be assigned in
+// time. Call SetConfig as the first statement of the binary's own init (or
+// main) instead. Package inits run sequentially on a single goroutine, so
+// this is race-free, and every name resolved afterwards, including the first
+// log line, uses the new config.
+//
 // # Usage pattern in a binary
 //
 // A typical component does:
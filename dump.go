@@ -0,0 +1,148 @@
+/*
+   Copyright 2025 The DIRPX Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rfx
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"dirpx.dev/rfx/apis"
+	"dirpx.dev/rfx/builder"
+	uref "dirpx.dev/rfx/utils/reflect"
+)
+
+// StateDump is the JSON document produced by DumpJSON.
+//
+// Its shape is stable: fields are only ever added, never renamed or removed.
+type StateDump struct {
+	// Config is the active configuration.
+	Config ConfigDump `json:"config"`
+	// RegistryPinned reports whether the registry is pinned.
+	RegistryPinned bool `json:"registryPinned"`
+	// ResolverPinned reports whether the resolver is pinned.
+	ResolverPinned bool `json:"resolverPinned"`
+	// ConfigPinned reports whether the configuration is pinned.
+	ConfigPinned bool `json:"configPinned"`
+	// Builder is the fully-qualified type name of the active builder, as
+	// reported by BuilderName (e.g. "dirpx.dev/rfx/builder.builder").
+	Builder string `json:"builder"`
+	// DefaultBuilder reports whether the active builder is the stock builder.
+	DefaultBuilder bool `json:"defaultBuilder"`
+	// Ext is the extension payload rendered with %+v; empty if no ext is set.
+	Ext string `json:"ext,omitempty"`
+	// Entries lists registry entries sorted by type, then name.
+	Entries []EntryDump `json:"entries"`
+}
+
+// ConfigDump is the apis.Config in a StateDump, with stable JSON keys.
+// Unsafe kinds are reported as the policy in effect, so the deprecated
+// RejectUnsafeKinds has no field of its own.
+type ConfigDump struct {
+	IncludeBuiltins        bool                  `json:"includeBuiltins"`
+	MaxUnwrap              int                   `json:"maxUnwrap"`
+	UnwrapFinalNamed       bool                  `json:"unwrapFinalNamed"`
+	MapPreferElem          bool                  `json:"mapPreferElem"`
+	NormalizeOutermost     bool                  `json:"normalizeOutermost"`
+	UnsafeKinds            apis.UnsafeKindPolicy `json:"unsafeKinds"`
+	KeepContainerMarkers   bool                  `json:"keepContainerMarkers"`
+	KeepArrayLen           bool                  `json:"keepArrayLen"`
+	DistinguishArrays      bool                  `json:"distinguishArrays"`
+	DistinguishPointers    bool                  `json:"distinguishPointers"`
+	PointerDepthInName     bool                  `json:"pointerDepthInName"`
+	KeepPointerDepth       bool                  `json:"keepPointerDepth"`
+	MaxNameLen             int                   `json:"maxNameLen"`
+	RegistryOverridesNamer bool                  `json:"registryOverridesNamer"`
+	GlobalPrefix           string                `json:"globalPrefix"`
+	DisableReflectFallback bool                  `json:"disableReflectFallback"`
+	TrimModuleVersion      bool                  `json:"trimModuleVersion"`
+	OmitPackage            bool                  `json:"omitPackage"`
+	FriendlyByteSlices     bool                  `json:"friendlyByteSlices"`
+	PkgPathHashSuffix      bool                  `json:"pkgPathHashSuffix"`
+	NameStyle              apis.NameStyle        `json:"nameStyle"`
+	TypeAliases            map[string]string     `json:"typeAliases,omitempty"`
+}
+
+// dumpConfig converts c for a StateDump.
+func dumpConfig(c apis.Config) ConfigDump {
+	return ConfigDump{
+		IncludeBuiltins:        c.IncludeBuiltins,
+		MaxUnwrap:              c.MaxUnwrap,
+		UnwrapFinalNamed:       c.UnwrapFinalNamed,
+		MapPreferElem:          c.MapPreferElem,
+		NormalizeOutermost:     c.NormalizeOutermost,
+		UnsafeKinds:            c.UnsafeKindPolicy(),
+		KeepContainerMarkers:   c.KeepContainerMarkers,
+		KeepArrayLen:           c.KeepArrayLen,
+		DistinguishArrays:      c.DistinguishArrays,
+		DistinguishPointers:    c.DistinguishPointers,
+		PointerDepthInName:     c.PointerDepthInName,
+		KeepPointerDepth:       c.KeepPointerDepth,
+		MaxNameLen:             c.MaxNameLen,
+		RegistryOverridesNamer: c.RegistryOverridesNamer,
+		GlobalPrefix:           c.GlobalPrefix,
+		DisableReflectFallback: c.DisableReflectFallback,
+		TrimModuleVersion:      c.TrimModuleVersion,
+		OmitPackage:            c.OmitPackage,
+		FriendlyByteSlices:     c.FriendlyByteSlices,
+		PkgPathHashSuffix:      c.PkgPathHashSuffix,
+		NameStyle:              c.NameStyle,
+		TypeAliases:            c.TypeAliases,
+	}
+}
+
+// EntryDump is a single registry entry in a StateDump.
+type EntryDump struct {
+	// Type is the registered type rendered as "pkgpath.Type".
+	Type string `json:"type"`
+	// Name is the associated name.
+	Name string `json:"name"`
+}
+
+// DumpJSON serializes the current global rfx snapshot as a StateDump.
+// All values are taken from a single snapshot, so the document is consistent.
+func DumpJSON() ([]byte, error) {
+	s := st.Load()
+
+	d := StateDump{
+		Config:         dumpConfig(s.cfg),
+		RegistryPinned: s.preg,
+		ResolverPinned: s.pres,
+		ConfigPinned:   s.pcfg,
+		Builder:        builderName(s.bld),
+		DefaultBuilder: reflect.TypeOf(s.bld) == defaultBuilderType,
+		Entries:        []EntryDump{},
+	}
+	if s.ext != nil {
+		d.Ext = fmt.Sprintf("%+v", s.ext)
+	}
+	for _, e := range s.reg.Entries() {
+		d.Entries = append(d.Entries, EntryDump{Type: uref.FullName(e.Type), Name: e.Name})
+	}
+	sort.Slice(d.Entries, func(i, j int) bool {
+		if d.Entries[i].Type != d.Entries[j].Type {
+			return d.Entries[i].Type < d.Entries[j].Type
+		}
+		return d.Entries[i].Name < d.Entries[j].Name
+	})
+
+	return json.Marshal(d)
+}
+
+// defaultBuilderType is the concrete type of the stock builder.
+var defaultBuilderType = reflect.TypeOf(builder.New())
@@ -0,0 +1,97 @@
+package rfx
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"dirpx.dev/rfx/apis"
+	"dirpx.dev/rfx/builder"
+	"dirpx.dev/rfx/config"
+)
+
+type dumpOrder struct{}
+
+func TestDumpJSON_ContainsEntries(t *testing.T) {
+	resetWithBuilder(t, builder.New(), config.DefaultConfig(), "ext-payload")
+	Registry().Reset()
+	if err := RegisterType(reflect.TypeOf(dumpOrder{}), "domain.order"); err != nil {
+		t.Fatalf("RegisterType: %v", err)
+	}
+	PinResolver()
+	defer UnpinResolver()
+	PinConfig()
+	defer UnpinConfig()
+
+	data, err := DumpJSON()
+	if err != nil {
+		t.Fatalf("DumpJSON: %v", err)
+	}
+
+	var d StateDump
+	if err := json.Unmarshal(data, &d); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if len(d.Entries) != 1 {
+		t.Fatalf("entries = %+v, want exactly one", d.Entries)
+	}
+	want := EntryDump{Type: "dirpx.dev/rfx.dumpOrder", Name: "domain.order"}
+	if d.Entries[0] != want {
+		t.Fatalf("entry = %+v, want %+v", d.Entries[0], want)
+	}
+	if !d.DefaultBuilder || d.Builder != "dirpx.dev/rfx/builder.builder" {
+		t.Fatalf("builder = (%q,%v), want (dirpx.dev/rfx/builder.builder,true)", d.Builder, d.DefaultBuilder)
+	}
+	if d.RegistryPinned || !d.ResolverPinned || !d.ConfigPinned {
+		t.Fatalf("pins = (%v,%v,%v), want (false,true,true)", d.RegistryPinned, d.ResolverPinned, d.ConfigPinned)
+	}
+	if d.Ext != "ext-payload" {
+		t.Fatalf("ext = %q, want %q", d.Ext, "ext-payload")
+	}
+	if d.Config.MaxUnwrap != config.DefaultMaxUnwrap {
+		t.Fatalf("config.MaxUnwrap = %d, want %d", d.Config.MaxUnwrap, config.DefaultMaxUnwrap)
+	}
+}
+
+func TestDumpJSON_CustomBuilder(t *testing.T) {
+	resetWithBuilder(t, &mockBuilder{}, config.DefaultConfig(), nil)
+	defer resetWithBuilder(t, builder.New(), config.DefaultConfig(), nil)
+
+	data, err := DumpJSON()
+	if err != nil {
+		t.Fatalf("DumpJSON: %v", err)
+	}
+	var d StateDump
+	if err := json.Unmarshal(data, &d); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if d.DefaultBuilder || d.Builder != "dirpx.dev/rfx.mockBuilder" {
+		t.Fatalf("builder = (%q,%v), want (dirpx.dev/rfx.mockBuilder,false)", d.Builder, d.DefaultBuilder)
+	}
+}
+
+func TestDumpJSON_ConfigKeys(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.GlobalPrefix = "tenant"
+	defer Capture().Restore()
+	resetWithBuilder(t, builder.New(), cfg, nil)
+
+	data, err := DumpJSON()
+	if err != nil {
+		t.Fatalf("DumpJSON: %v", err)
+	}
+	if !strings.Contains(string(data), `"globalPrefix":"tenant"`) {
+		t.Fatalf("dump lacks the tagged config key: %s", data)
+	}
+
+	// Every apis.Config field except the deprecated RejectUnsafeKinds has a
+	// counterpart, so new knobs are not silently left out of the dump.
+	ct, dt := reflect.TypeOf(apis.Config{}), reflect.TypeOf(ConfigDump{})
+	for i := 0; i < ct.NumField(); i++ {
+		name := ct.Field(i).Name
+		if _, ok := dt.FieldByName(name); !ok && name != "RejectUnsafeKinds" {
+			t.Errorf("ConfigDump lacks %s", name)
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package rfx
+
+import (
+	"reflect"
+	"testing"
+	"time"
+	"unsafe"
+
+	"dirpx.dev/rfx/apis"
+	"dirpx.dev/rfx/builder"
+	"dirpx.dev/rfx/config"
+)
+
+type (
+	exactStruct         struct{}
+	exactIface          interface{ M() }
+	exactInt            int
+	exactFunc           func()
+	exactGeneric[T any] struct{ V T }
+	exactRegistered     struct{}
+	exactHandle         uintptr
+)
+
+func TestEntityTypeExact_MatchesEntityType(t *testing.T) {
+	defer Capture().Restore()
+
+	types := []reflect.Type{
+		reflect.TypeOf(exactStruct{}),
+		reflect.TypeOf((*exactIface)(nil)).Elem(),
+		reflect.TypeOf(exactInt(0)),
+		reflect.TypeOf(exactFunc(nil)),
+		reflect.TypeOf(exactGeneric[int]{}),
+		reflect.TypeOf(exactRegistered{}),
+		reflect.TypeOf(exactHandle(0)),
+		reflect.TypeOf(time.Duration(0)),
+		reflect.TypeOf((*error)(nil)).Elem(),
+		reflect.TypeOf(0),
+		reflect.TypeOf(""),
+		reflect.TypeOf(uintptr(0)),
+		reflect.TypeOf(unsafe.Pointer(nil)),
+		// Not exact: these fall through to EntityType.
+		reflect.TypeOf(&exactStruct{}),
+		reflect.TypeOf([]exactStruct{}),
+		reflect.TypeOf(map[string]exactStruct{}),
+		reflect.TypeOf(struct{}{}),
+		nil,
+	}
+
+	with := func(f func(*apis.Config)) apis.Config {
+		c := config.DefaultConfig()
+		f(&c)
+		return c
+	}
+	configs := map[string]apis.Config{
+		"default":        config.DefaultConfig(),
+		"builtins":       with(func(c *apis.Config) { c.IncludeBuiltins = true }),
+		"omit package":   with(func(c *apis.Config) { c.OmitPackage = true }),
+		"hash suffix":    with(func(c *apis.Config) { c.PkgPathHashSuffix = true }),
+		"aliases":        with(func(c *apis.Config) { c.TypeAliases = map[string]string{"rfx.exactStruct": "alias.S"} }),
+		"max name len":   with(func(c *apis.Config) { c.MaxNameLen = 8 }),
+		"no reflect":     with(func(c *apis.Config) { c.DisableReflectFallback = true }),
+		"unsafe fixed":   with(func(c *apis.Config) { c.UnsafeKinds = apis.UnsafeKindFixedName }),
+		"unsafe error":   with(func(c *apis.Config) { c.UnsafeKinds = apis.UnsafeKindError }),
+		"no unwrap":      with(func(c *apis.Config) { c.MaxUnwrap = 0 }),
+		"markers":        with(func(c *apis.Config) { c.KeepContainerMarkers = true; c.DistinguishPointers = true }),
+		"registry first": with(func(c *apis.Config) { c.RegistryOverridesNamer = true }),
+	}
+
+	for cname, cfg := range configs {
+		resetWithBuilder(t, builder.New(), cfg, nil)
+		Registry().Reset()
+		if err := RegisterType(reflect.TypeOf(exactRegistered{}), "exact.registered"); err != nil {
+			t.Fatal(err)
+		}
+		for _, typ := range types {
+			if got, want := EntityTypeExact(typ), EntityType(typ); got != want {
+				t.Errorf("%s: EntityTypeExact(%v) = %q, EntityType = %q", cname, typ, got, want)
+			}
+		}
+	}
+}
+
+func BenchmarkEntityTypeExact(b *testing.B) {
+	defer Capture().Restore()
+	resetWithBuilder(b, builder.New(), config.DefaultConfig(), nil)
+	Registry().Reset()
+	registered := reflect.TypeOf(exactRegistered{})
+	if err := RegisterType(registered, "exact.registered"); err != nil {
+		b.Fatal(err)
+	}
+
+	for _, bc := range []struct {
+		name string
+		typ  reflect.Type
+	}{
+		{"registered", registered},
+		{"reflect", reflect.TypeOf(exactStruct{})},
+	} {
+		b.Run(bc.name+"/EntityType", func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				_ = EntityType(bc.typ)
+			}
+		})
+		b.Run(bc.name+"/EntityTypeExact", func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				_ = EntityTypeExact(bc.typ)
+			}
+		})
+	}
+}
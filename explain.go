@@ -0,0 +1,82 @@
+/*
+   Copyright 2025 The DIRPX Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rfx
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+
+	"dirpx.dev/rfx/apis"
+	uref "dirpx.dev/rfx/utils/reflect"
+)
+
+// Explain returns a human-readable trace of how v resolves under the global
+// rfx state, for support and debugging, e.g.
+//
+//	*[]pkg.User -> unwrap ptr -> unwrap slice -> named pkg.User -> namer miss -> registry miss -> reflect: "pkg.User"
+//
+// The normalization steps follow the global rfx configuration; then v is
+// resolved once by the global rfx res, and its strategies are listed up to the
+// one that produced the name, as reported by apis.SourceResolver. Strategies
+// are never run outside the resolver, so the result always matches Entity.
+// For other resolvers only the final result is reported. Explain allocates
+// freely; do not use it on hot paths.
+func Explain(v any) string {
+	s := load()
+	if v == nil {
+		return "<nil> -> " + strconv.Quote(s.res.Resolve(nil, s.cfg))
+	}
+
+	t := reflect.TypeOf(v)
+	steps := []string{t.String()}
+	if base, layers, err := uref.NormalizeDetailed(t, s.cfg); err != nil {
+		steps = append(steps, "normalize: "+err.Error())
+	} else {
+		for _, l := range layers {
+			steps = append(steps, "unwrap "+kindWord(l.Kind()))
+		}
+		steps = append(steps, "named "+base.String())
+	}
+
+	name, src := resolveSource(s.res, v, s.cfg)
+	if src < 0 && name != "" {
+		steps = append(steps, "resolver: "+strconv.Quote(name))
+		return strings.Join(steps, " -> ")
+	}
+	var strats []apis.Strategy
+	if l, ok := s.res.(apis.StrategyLister); ok {
+		strats = l.Strategies()
+	}
+	for i, strat := range strats {
+		if i == src {
+			steps = append(steps, strategyName(strat)+": "+strconv.Quote(name))
+			return strings.Join(steps, " -> ")
+		}
+		steps = append(steps, strategyName(strat)+" miss")
+	}
+	steps = append(steps, `unresolved: ""`)
+	return strings.Join(steps, " -> ")
+}
+
+// kindWord returns the short word for a container kind used by Explain.
+func kindWord(k reflect.Kind) string {
+	if k == reflect.Ptr {
+		return "ptr"
+	}
+	return k.String()
+}
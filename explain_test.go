@@ -0,0 +1,51 @@
+package rfx
+
+import (
+	"reflect"
+	"testing"
+
+	"dirpx.dev/rfx/builder"
+	"dirpx.dev/rfx/config"
+	"dirpx.dev/rfx/resolver"
+	"dirpx.dev/rfx/strategy"
+)
+
+func TestExplain(t *testing.T) {
+	defer Capture().Restore()
+	resetWithBuilder(t, builder.New(), config.DefaultConfig(), nil)
+	Registry().Reset()
+	_ = RegisterType(reflect.TypeOf(derivedB{}), "explained.b")
+
+	cases := []struct {
+		v    any
+		want string
+	}{
+		{&[]derivedA{}, `*[]rfx.derivedA -> unwrap ptr -> unwrap slice -> named rfx.derivedA -> namer miss -> registry miss -> reflect: "rfx.derivedA"`},
+		{derivedB{}, `rfx.derivedB -> named rfx.derivedB -> namer miss -> registry: "explained.b"`},
+		{nilOrder{}, `rfx.nilOrder -> named rfx.nilOrder -> namer: "nil.order"`},
+		{[]struct{}{}, `[]struct {} -> normalize: reflect: type has no registered name -> namer miss -> registry miss -> reflect: ""`},
+		{nil, `<nil> -> ""`},
+	}
+	for _, tc := range cases {
+		if got := Explain(tc.v); got != tc.want {
+			t.Errorf("Explain(%T):\n got %s\nwant %s", tc.v, got, tc.want)
+		}
+	}
+
+	SetResolver(resolver.New()) // no strategies
+	if got, want := Explain(derivedA{}), `rfx.derivedA -> named rfx.derivedA -> unresolved: ""`; got != want {
+		t.Errorf("Explain with empty chain:\n got %s\nwant %s", got, want)
+	}
+}
+
+func TestExplain_MatchesResolver(t *testing.T) {
+	defer Capture().Restore()
+	resetWithBuilder(t, builder.New(), config.DefaultConfig(), nil)
+	SetResolver(resolver.NewSafe(tracePanicStrategy{}, strategy.NewReflectStrategy()))
+
+	// The panic is recovered by the resolver, not by Explain.
+	want := `rfx.derivedA -> named rfx.derivedA -> rfx.tracePanicStrategy miss -> reflect: "rfx.derivedA"`
+	if got := Explain(derivedA{}); got != want {
+		t.Fatalf("Explain:\n got %s\nwant %s", got, want)
+	}
+}
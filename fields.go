@@ -0,0 +1,52 @@
+/*
+   Copyright 2025 The DIRPX Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rfx
+
+import "dirpx.dev/rfx/apis"
+
+// Structured field keys produced by Fields.
+const (
+	FieldEntity         = "entity"
+	FieldEntityID       = "entity_id"
+	FieldEntityVersion  = "entity_version"
+	FieldEntityCategory = "entity_category"
+)
+
+// Fields returns structured logging fields describing v:
+//   - "entity": the name resolved by the global rfx res;
+//   - "entity_id": from apis.Identifier;
+//   - "entity_version" and "entity_category": from apis.Describer.
+//
+// Empty values are omitted, so the result may be empty but is never nil.
+func Fields(v any) map[string]string {
+	f := make(map[string]string, 4)
+	put := func(k, val string) {
+		if val != "" {
+			f[k] = val
+		}
+	}
+
+	put(FieldEntity, Entity(v))
+	if id, ok := v.(apis.Identifier); ok {
+		put(FieldEntityID, id.EntityID())
+	}
+	if d, ok := v.(apis.Describer); ok {
+		put(FieldEntityVersion, d.EntityVersion())
+		put(FieldEntityCategory, d.EntityCategory())
+	}
+	return f
+}
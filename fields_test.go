@@ -0,0 +1,49 @@
+package rfx
+
+import (
+	"reflect"
+	"testing"
+
+	"dirpx.dev/rfx/builder"
+	"dirpx.dev/rfx/config"
+)
+
+type fullEntity struct{}
+
+func (fullEntity) EntityName() string        { return "cache.entry" }
+func (fullEntity) EntityID() string          { return "abc123" }
+func (fullEntity) EntityVersion() string     { return "v2" }
+func (fullEntity) EntityCategory() string    { return "" }
+func (fullEntity) EntityDescription() string { return "a cache entry" }
+
+type namerOnly struct{}
+
+func (namerOnly) EntityName() string { return "cache.key" }
+
+func TestFields_AllInterfaces(t *testing.T) {
+	resetWithBuilder(t, builder.New(), config.DefaultConfig(), nil)
+
+	got := Fields(fullEntity{})
+	want := map[string]string{
+		FieldEntity:        "cache.entry",
+		FieldEntityID:      "abc123",
+		FieldEntityVersion: "v2",
+		// entity_category is empty and therefore omitted.
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("Fields() = %v, want %v", got, want)
+	}
+}
+
+func TestFields_NamerOnly(t *testing.T) {
+	resetWithBuilder(t, builder.New(), config.DefaultConfig(), nil)
+
+	got := Fields(namerOnly{})
+	want := map[string]string{FieldEntity: "cache.key"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("Fields() = %v, want %v", got, want)
+	}
+	if got := Fields(nil); got == nil || len(got) != 0 {
+		t.Fatalf("Fields(nil) = %v, want empty non-nil map", got)
+	}
+}
@@ -0,0 +1,194 @@
+/*
+   Copyright 2025 The DIRPX Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rfx
+
+import (
+	"reflect"
+	"sync/atomic"
+
+	"dirpx.dev/rfx/apis"
+	"dirpx.dev/rfx/registry"
+)
+
+// ErrFrozen is returned (or panicked with) by mutations after Freeze. It is
+// registry.ErrFrozen, which a frozen registry returns to callers holding it
+// from before Freeze.
+var ErrFrozen = registry.ErrFrozen
+
+var (
+	// frozen is set once by Freeze and never cleared outside tests.
+	frozen atomic.Bool
+	// freezePanics makes mutations panic with ErrFrozen instead of failing quietly.
+	freezePanics atomic.Bool
+)
+
+// FreezeOption configures Freeze.
+type FreezeOption func(*freezeOptions)
+
+// freezeOptions holds the settings applied by FreezeOption values.
+type freezeOptions struct {
+	panics bool
+}
+
+// FreezePanics makes every mutation attempted after Freeze panic with
+// ErrFrozen instead of being ignored (or returning ErrFrozen).
+func FreezePanics() FreezeOption {
+	return func(o *freezeOptions) {
+		o.panics = true
+	}
+}
+
+// Freeze permanently forbids changes to global naming behavior.
+//
+// After Freeze:
+//   - RegisterType, RegisterDerived and every write through Registry()
+//     (Register, Reset, RegisterDisplay, RegisterDescribed, RegisterLazy,
+//     Unregister) return ErrFrozen or do nothing;
+//   - SetAll, SetConfig, SetReflectFallback, SetRegistry, SetResolver,
+//     SetResolverAndRegistry, SetBuilder, SetExt, the Pin/Unpin functions and
+//     Restorer.Restore do nothing;
+//   - WithConfigScope runs fn with the global configuration.
+//
+// The registry itself is frozen too if it implements apis.Freezer, as every
+// registry in the registry package does, so a handle obtained from Registry()
+// before Freeze cannot write either. Writes through such a handle to a
+// registry without Freezer are not prevented.
+//
+// With FreezePanics, all of the above panic with ErrFrozen instead.
+// Reads are unaffected. Freezing is one-way for the life of the process,
+// which makes it stronger than pinning; calling Freeze again is a no-op.
+func Freeze(opts ...FreezeOption) {
+	buildMu.Lock()
+	defer buildMu.Unlock()
+	if frozen.Load() {
+		return
+	}
+
+	var o freezeOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	freezePanics.Store(o.panics)
+
+	// Guard direct registry access through Registry(), and earlier handles
+	// through the registry itself.
+	old := st.Load()
+	if f, ok := old.reg.(apis.Freezer); ok {
+		f.Freeze()
+	}
+	st.Store(
+		&state{
+			cfg:   old.cfg,
+			ext:   old.ext,
+			reg:   frozenRegistry{registry.Wrapper{Registry: old.reg, Config: old.cfg}},
+			res:   old.res,
+			bld:   old.bld,
+			preg:  old.preg,
+			pres:  old.pres,
+			pcfg:  old.pcfg,
+			noref: old.noref,
+		},
+	)
+	frozen.Store(true)
+}
+
+// IsFrozen reports whether Freeze has been called.
+func IsFrozen() bool {
+	return frozen.Load()
+}
+
+// frozenGuard reports whether mutations are forbidden, panicking with
+// ErrFrozen if Freeze was called with FreezePanics.
+func frozenGuard() bool {
+	if !frozen.Load() {
+		return false
+	}
+	_ = frozenErr()
+	return true
+}
+
+// frozenErr returns ErrFrozen, or panics with it under FreezePanics.
+func frozenErr() error {
+	if freezePanics.Load() {
+		panic(ErrFrozen)
+	}
+	return ErrFrozen
+}
+
+// unfreeze lifts Freeze; it exists for tests only.
+func unfreeze() {
+	buildMu.Lock()
+	defer buildMu.Unlock()
+	if old := st.Load(); old != nil {
+		if fr, ok := old.reg.(frozenRegistry); ok {
+			st.Store(
+				&state{
+					cfg:   old.cfg,
+					ext:   old.ext,
+					reg:   fr.Registry,
+					res:   old.res,
+					bld:   old.bld,
+					preg:  old.preg,
+					pres:  old.pres,
+					pcfg:  old.pcfg,
+					noref: old.noref,
+				},
+			)
+		}
+	}
+	frozen.Store(false)
+	freezePanics.Store(false)
+}
+
+// frozenRegistry rejects writes to the wrapped registry. Reads, including
+// the optional registry interfaces, are forwarded by the embedded
+// registry.Wrapper.
+type frozenRegistry struct {
+	registry.Wrapper
+}
+
+// Register returns ErrFrozen.
+func (frozenRegistry) Register(reflect.Type, string) error {
+	return frozenErr()
+}
+
+// Reset does nothing.
+func (frozenRegistry) Reset() {
+	_ = frozenErr()
+}
+
+// RegisterDisplay returns ErrFrozen.
+func (frozenRegistry) RegisterDisplay(reflect.Type, string, string) error {
+	return frozenErr()
+}
+
+// RegisterDescribed returns ErrFrozen.
+func (frozenRegistry) RegisterDescribed(reflect.Type, apis.Description) error {
+	return frozenErr()
+}
+
+// RegisterLazy returns ErrFrozen. Pending lazy entries still resolve on
+// their first Lookup.
+func (frozenRegistry) RegisterLazy(reflect.Type, func() (string, error)) error {
+	return frozenErr()
+}
+
+// Unregister does nothing and reports false.
+func (frozenRegistry) Unregister(reflect.Type) bool {
+	_ = frozenErr()
+	return false
+}
@@ -0,0 +1,162 @@
+package rfx
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"dirpx.dev/rfx/apis"
+	"dirpx.dev/rfx/builder"
+	"dirpx.dev/rfx/config"
+)
+
+type frozenType struct{}
+
+func TestFreeze_ForbidsMutationsKeepsReads(t *testing.T) {
+	restore := Capture()
+	defer restore.Restore()
+	defer unfreeze()
+	resetWithBuilder(t, builder.New(), config.DefaultConfig(), nil)
+	Registry().Reset()
+	_ = RegisterType(reflect.TypeOf(derivedA{}), "frozen.a")
+
+	Freeze()
+	if !IsFrozen() {
+		t.Fatal("IsFrozen() = false after Freeze")
+	}
+	before := st.Load()
+
+	if err := RegisterType(reflect.TypeOf(frozenType{}), "x"); !errors.Is(err, ErrFrozen) {
+		t.Fatalf("RegisterType: want ErrFrozen, got %v", err)
+	}
+	if _, err := RegisterDerived(reflect.TypeOf(frozenType{})); !errors.Is(err, ErrFrozen) {
+		t.Fatalf("RegisterDerived: want ErrFrozen, got %v", err)
+	}
+	if err := Registry().Register(reflect.TypeOf(frozenType{}), "x"); !errors.Is(err, ErrFrozen) {
+		t.Fatalf("Registry().Register: want ErrFrozen, got %v", err)
+	}
+	Registry().Reset()
+
+	cfg := config.DefaultConfig()
+	SetConfig(apis.Config{MaxUnwrap: 1})
+	SetAll(&cfg, nil, nil, nil, &mockBuilder{})
+	SetBuilder(&mockBuilder{})
+	SetRegistry(newMockRegistry("x"))
+	SetResolver(&mockResolver{id: "x"})
+	SetResolverAndRegistry(&mockResolver{id: "x"}, newMockRegistry("x"))
+	SetExt("x")
+	SetReflectFallback(false)
+	PinRegistry()
+	UnpinResolver()
+	restore.Restore()
+
+	if st.Load() != before {
+		t.Fatal("state changed after Freeze")
+	}
+
+	// Reads keep working, including the pre-freeze registration.
+	if got := Entity(derivedA{}); got != "frozen.a" {
+		t.Fatalf("Entity(derivedA) = %q, want frozen.a", got)
+	}
+	if got := Entity(frozenType{}); got != "rfx.frozenType" {
+		t.Fatalf("Entity(frozenType) = %q", got)
+	}
+	if Registry().Count() != 1 {
+		t.Fatalf("Count = %d, want 1", Registry().Count())
+	}
+}
+
+func TestFreeze_PanicsWhenConfigured(t *testing.T) {
+	defer Capture().Restore()
+	defer unfreeze()
+	resetWithBuilder(t, builder.New(), config.DefaultConfig(), nil)
+
+	Freeze(FreezePanics())
+
+	for name, fn := range map[string]func(){
+		"SetConfig":    func() { SetConfig(config.DefaultConfig()) },
+		"RegisterType": func() { _ = RegisterType(reflect.TypeOf(frozenType{}), "x") },
+		"Register":     func() { _ = Registry().Register(reflect.TypeOf(frozenType{}), "x") },
+		"Unregister":   func() { Registry().(apis.Unregisterer).Unregister(reflect.TypeOf(frozenType{})) },
+	} {
+		func() {
+			defer func() {
+				if r := recover(); r != ErrFrozen {
+					t.Errorf("%s: recovered %v, want ErrFrozen", name, r)
+				}
+			}()
+			fn()
+		}()
+	}
+
+	// Reads never panic.
+	_ = Entity(frozenType{})
+}
+
+func TestFreeze_OptionalRegistryInterfaces(t *testing.T) {
+	defer Capture().Restore()
+	defer unfreeze()
+	resetWithBuilder(t, builder.New(), config.DefaultConfig(), nil)
+	Registry().Reset()
+	_ = RegisterType(reflect.TypeOf(derivedA{}), "frozen.a")
+
+	Freeze()
+	reg := Registry()
+	typ := reflect.TypeOf(derivedA{})
+
+	// Reads delegate to the wrapped registry.
+	if got, ok := reg.(apis.Snapshotter).Snapshot().Lookup(typ); !ok || got != "frozen.a" {
+		t.Fatalf("Snapshot().Lookup = (%q,%v), want (frozen.a,true)", got, ok)
+	}
+	pq := reg.(apis.PrefixQuerier)
+	if n := pq.CountByPrefix("frozen."); n != 1 {
+		t.Fatalf("CountByPrefix = %d, want 1", n)
+	}
+	if e := pq.EntriesByPrefix("frozen."); len(e) != 1 || e[0].Name != "frozen.a" {
+		t.Fatalf("EntriesByPrefix = %+v", e)
+	}
+	var buf bytes.Buffer
+	if err := reg.(apis.EntryWriter).WriteEntries(&buf, "jsonl"); err != nil || !strings.Contains(buf.String(), "frozen.a") {
+		t.Fatalf("WriteEntries = %v, %q", err, buf.String())
+	}
+	unsubscribe := reg.(apis.Subscriber).Subscribe(func(apis.RegistryEvent) {})
+	unsubscribe()
+	if p := reg.(apis.LazyRegistry).PendingLazy(); len(p) != 0 {
+		t.Fatalf("PendingLazy = %+v, want none", p)
+	}
+
+	// Writes are rejected.
+	lazy := func() (string, error) { return "x", nil }
+	if err := reg.(apis.LazyRegistry).RegisterLazy(reflect.TypeOf(frozenType{}), lazy); !errors.Is(err, ErrFrozen) {
+		t.Fatalf("RegisterLazy: want ErrFrozen, got %v", err)
+	}
+	if reg.(apis.Unregisterer).Unregister(typ) {
+		t.Fatal("Unregister reported true after Freeze")
+	}
+	if got := Entity(derivedA{}); got != "frozen.a" {
+		t.Fatalf("Entity(derivedA) = %q after Unregister, want frozen.a", got)
+	}
+}
+
+func TestFreeze_EarlierRegistryHandle(t *testing.T) {
+	defer Capture().Restore()
+	defer unfreeze()
+	resetWithBuilder(t, builder.New(), config.DefaultConfig(), nil)
+	_ = RegisterType(reflect.TypeOf(derivedA{}), "frozen.a")
+	reg := Registry()
+
+	Freeze()
+
+	if err := reg.Register(reflect.TypeOf(frozenType{}), "x"); !errors.Is(err, ErrFrozen) {
+		t.Fatalf("Register: want ErrFrozen, got %v", err)
+	}
+	reg.Reset()
+	if reg.(apis.Unregisterer).Unregister(reflect.TypeOf(derivedA{})) {
+		t.Fatal("Unregister reported true after Freeze")
+	}
+	if got := Entity(derivedA{}); got != "frozen.a" {
+		t.Fatalf("Entity(derivedA) = %q, want frozen.a", got)
+	}
+}
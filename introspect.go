@@ -0,0 +1,62 @@
+/*
+   Copyright 2025 The DIRPX Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rfx
+
+import (
+	"reflect"
+
+	"dirpx.dev/rfx/apis"
+	uref "dirpx.dev/rfx/utils/reflect"
+)
+
+// BuilderName returns the fully-qualified type name of the global rfx bld,
+// e.g. "dirpx.dev/rfx/builder.builder" for the default builder, so
+// diagnostics can tell a custom builder from the stock one. Pointer
+// receivers are peeled. A nil builder yields "<nil>".
+func BuilderName() string {
+	return builderName(st.Load().bld)
+}
+
+// builderName implements BuilderName for b.
+func builderName(b apis.Builder) string {
+	if b == nil {
+		return "<nil>"
+	}
+	t := reflect.TypeOf(b)
+	for t.Kind() == reflect.Ptr && t.Name() == "" {
+		t = t.Elem()
+	}
+	return uref.FullName(t)
+}
+
+// ResolverStrategyNames returns the ordered names of the strategies behind the
+// global rfx res, e.g. ["namer", "registry", "reflect"].
+// Strategies implementing apis.NamedStrategy report their own name; others are
+// rendered as their concrete type name. If the resolver does not implement
+// apis.StrategyLister, nil is returned.
+func ResolverStrategyNames() []string {
+	l, ok := st.Load().res.(apis.StrategyLister)
+	if !ok {
+		return nil
+	}
+	strats := l.Strategies()
+	names := make([]string, 0, len(strats))
+	for _, s := range strats {
+		names = append(names, strategyName(s))
+	}
+	return names
+}
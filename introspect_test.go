@@ -0,0 +1,42 @@
+package rfx
+
+import (
+	"reflect"
+	"testing"
+
+	"dirpx.dev/rfx/builder"
+	"dirpx.dev/rfx/config"
+)
+
+func TestResolverStrategyNames_Default(t *testing.T) {
+	resetWithBuilder(t, builder.New(), config.DefaultConfig(), nil)
+
+	got := ResolverStrategyNames()
+	want := []string{"namer", "registry", "reflect"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("ResolverStrategyNames() = %v, want %v", got, want)
+	}
+}
+
+func TestResolverStrategyNames_Unsupported(t *testing.T) {
+	resetWithBuilder(t, &mockBuilder{}, config.DefaultConfig(), nil)
+	defer resetWithBuilder(t, builder.New(), config.DefaultConfig(), nil)
+
+	if got := ResolverStrategyNames(); got != nil {
+		t.Fatalf("ResolverStrategyNames() = %v, want nil", got)
+	}
+}
+
+func TestBuilderName(t *testing.T) {
+	defer Capture().Restore()
+
+	resetWithBuilder(t, builder.New(), config.DefaultConfig(), nil)
+	if got, want := BuilderName(), "dirpx.dev/rfx/builder.builder"; got != want {
+		t.Fatalf("BuilderName() default = %q, want %q", got, want)
+	}
+
+	resetWithBuilder(t, &mockBuilder{}, config.DefaultConfig(), nil)
+	if got, want := BuilderName(), "dirpx.dev/rfx.mockBuilder"; got != want {
+		t.Fatalf("BuilderName() mock = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,46 @@
+package rfx
+
+import (
+	"testing"
+
+	"dirpx.dev/rfx/apis"
+	"dirpx.dev/rfx/builder"
+	"dirpx.dev/rfx/config"
+)
+
+// prevExtBuilder records the ext pairs it was called with.
+type prevExtBuilder struct {
+	apis.Builder
+	regCalls [][2]any
+	resCalls [][2]any
+}
+
+func (b *prevExtBuilder) BuildRegistryWithPrevExt(cfg apis.Config, reg apis.Registry, prevExt, ext any) apis.Registry {
+	b.regCalls = append(b.regCalls, [2]any{prevExt, ext})
+	return b.Builder.BuildRegistry(cfg, reg, ext)
+}
+
+func (b *prevExtBuilder) BuildResolverWithPrevExt(cfg apis.Config, reg apis.Registry, res apis.Resolver, prevExt, ext any) apis.Resolver {
+	b.resCalls = append(b.resCalls, [2]any{prevExt, ext})
+	return b.Builder.BuildResolver(cfg, reg, res, ext)
+}
+
+func TestPrevExtBuilder_ReceivesPreviousExt(t *testing.T) {
+	defer Capture().Restore()
+	resetWithBuilder(t, builder.New(), config.DefaultConfig(), "a")
+
+	pb := &prevExtBuilder{Builder: builder.New()}
+	SetBuilder(pb)
+	SetExt("b")
+	SetConfig(config.DefaultConfig())
+
+	want := [][2]any{{"a", "a"}, {"a", "b"}, {"b", "b"}}
+	for i, w := range want {
+		if pb.regCalls[i] != w {
+			t.Fatalf("registry call %d = %v, want %v", i, pb.regCalls[i], w)
+		}
+		if pb.resCalls[i] != w {
+			t.Fatalf("resolver call %d = %v, want %v", i, pb.resCalls[i], w)
+		}
+	}
+}
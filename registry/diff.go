@@ -0,0 +1,102 @@
+/*
+   Copyright 2025 The DIRPX Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package registry
+
+import (
+	"reflect"
+	"sort"
+
+	"dirpx.dev/rfx/apis"
+	uref "dirpx.dev/rfx/utils/reflect"
+)
+
+// RegistryDiff describes how two registries differ.
+// All slices are sorted by the full type name ("pkgpath.Type").
+type RegistryDiff struct {
+	// OnlyInA lists entries whose type is registered in a but not in b.
+	OnlyInA []apis.Entry
+	// OnlyInB lists entries whose type is registered in b but not in a.
+	OnlyInB []apis.Entry
+	// Changed lists types registered in both under different names.
+	Changed []NameChange
+}
+
+// NameChange is a type registered under different names in two registries.
+type NameChange struct {
+	Type reflect.Type
+	A    string
+	B    string
+}
+
+// Empty reports whether the registries compared equal.
+func (d RegistryDiff) Empty() bool {
+	return len(d.OnlyInA) == 0 && len(d.OnlyInB) == 0 && len(d.Changed) == 0
+}
+
+// Diff compares the entries of a and b by normalized type. It is intended
+// for golden-file checks of a runtime registry. A nil registry is treated as
+// empty.
+func Diff(a, b apis.Registry) RegistryDiff {
+	am, bm := entryMap(a), entryMap(b)
+
+	var d RegistryDiff
+	for t, an := range am {
+		bn, ok := bm[t]
+		switch {
+		case !ok:
+			d.OnlyInA = append(d.OnlyInA, apis.Entry{Type: t, Name: an})
+		case an != bn:
+			d.Changed = append(d.Changed, NameChange{Type: t, A: an, B: bn})
+		}
+	}
+	for t, bn := range bm {
+		if _, ok := am[t]; !ok {
+			d.OnlyInB = append(d.OnlyInB, apis.Entry{Type: t, Name: bn})
+		}
+	}
+
+	sortEntries(d.OnlyInA)
+	sortEntries(d.OnlyInB)
+	sort.Slice(d.Changed, func(i, j int) bool {
+		return uref.FullName(d.Changed[i].Type) < uref.FullName(d.Changed[j].Type)
+	})
+	return d
+}
+
+// entryMap indexes the entries of r by type.
+func entryMap(r apis.Registry) map[reflect.Type]string {
+	if r == nil {
+		return nil
+	}
+	entries := r.Entries()
+	m := make(map[reflect.Type]string, len(entries))
+	for _, e := range entries {
+		m[e.Type] = e.Name
+	}
+	return m
+}
+
+// sortEntries orders entries by full type name, then by name.
+func sortEntries(es []apis.Entry) {
+	sort.Slice(es, func(i, j int) bool {
+		ti, tj := uref.FullName(es[i].Type), uref.FullName(es[j].Type)
+		if ti != tj {
+			return ti < tj
+		}
+		return es[i].Name < es[j].Name
+	})
+}
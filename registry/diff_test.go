@@ -0,0 +1,84 @@
+/*
+   Copyright 2025 The DIRPX Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package registry_test
+
+import (
+	"reflect"
+	"testing"
+
+	"dirpx.dev/rfx/apis"
+	"dirpx.dev/rfx/config"
+	"dirpx.dev/rfx/registry"
+)
+
+func TestDiff(t *testing.T) {
+	a := registry.New(config.DefaultConfig())
+	b := registry.New(config.DefaultConfig())
+
+	must := func(r apis.Registry, v any, name string) {
+		t.Helper()
+		if err := r.Register(reflect.TypeOf(v), name); err != nil {
+			t.Fatalf("Register(%T): %v", v, err)
+		}
+	}
+	// Shared and unchanged.
+	must(a, T0{}, "t0")
+	must(b, T0{}, "t0")
+	// Removed (only in a), in reverse order to exercise sorting.
+	must(a, T2{}, "t2")
+	must(a, T1{}, "t1")
+	// Added (only in b).
+	must(b, T3{}, "t3")
+	// Renamed.
+	must(a, T4{}, "t4")
+	must(b, T4{}, "t4.renamed")
+
+	d := registry.Diff(a, b)
+	wantA := []apis.Entry{
+		{Type: reflect.TypeOf(T1{}), Name: "t1"},
+		{Type: reflect.TypeOf(T2{}), Name: "t2"},
+	}
+	wantB := []apis.Entry{{Type: reflect.TypeOf(T3{}), Name: "t3"}}
+	wantChanged := []registry.NameChange{{Type: reflect.TypeOf(T4{}), A: "t4", B: "t4.renamed"}}
+
+	if !reflect.DeepEqual(d.OnlyInA, wantA) {
+		t.Errorf("OnlyInA = %v, want %v", d.OnlyInA, wantA)
+	}
+	if !reflect.DeepEqual(d.OnlyInB, wantB) {
+		t.Errorf("OnlyInB = %v, want %v", d.OnlyInB, wantB)
+	}
+	if !reflect.DeepEqual(d.Changed, wantChanged) {
+		t.Errorf("Changed = %v, want %v", d.Changed, wantChanged)
+	}
+	if d.Empty() {
+		t.Error("Empty() = true, want false")
+	}
+}
+
+func TestDiff_Equal(t *testing.T) {
+	a := registry.New(config.DefaultConfig())
+	_ = a.Register(reflect.TypeOf(T0{}), "t0")
+	b := registry.NewSharded(config.DefaultConfig(), 2)
+	_ = b.Register(reflect.TypeOf(&T0{}), "t0")
+
+	if d := registry.Diff(a, b); !d.Empty() {
+		t.Fatalf("Diff = %+v, want empty", d)
+	}
+	if d := registry.Diff(nil, nil); !d.Empty() {
+		t.Fatalf("Diff(nil, nil) = %+v, want empty", d)
+	}
+}
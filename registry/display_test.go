@@ -0,0 +1,67 @@
+/*
+   Copyright 2025 The DIRPX Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package registry_test
+
+import (
+	"reflect"
+	"testing"
+
+	"dirpx.dev/rfx/apis"
+	"dirpx.dev/rfx/config"
+	"dirpx.dev/rfx/registry"
+)
+
+func TestRegisterDisplay_AndLookup(t *testing.T) {
+	reg := registry.New(config.DefaultConfig()).(apis.DisplayRegistry)
+
+	if err := reg.RegisterDisplay(reflect.TypeOf(&T1{}), "domain.user", "User Account"); err != nil {
+		t.Fatalf("RegisterDisplay: %v", err)
+	}
+	// Canonical name is what Lookup returns.
+	if name, ok := reg.Lookup(reflect.TypeOf(T1{})); !ok || name != "domain.user" {
+		t.Fatalf("Lookup: got (%q,%v), want (domain.user,true)", name, ok)
+	}
+	if disp, ok := reg.LookupDisplay(reflect.TypeOf([]T1{})); !ok || disp != "User Account" {
+		t.Fatalf("LookupDisplay: got (%q,%v), want (User Account,true)", disp, ok)
+	}
+
+	// Types registered without a display name have none.
+	_ = reg.Register(reflect.TypeOf(T2{}), "domain.t2")
+	if disp, ok := reg.LookupDisplay(reflect.TypeOf(T2{})); ok || disp != "" {
+		t.Fatalf("LookupDisplay(T2): got (%q,%v), want ('',false)", disp, ok)
+	}
+
+	reg.Reset()
+	if _, ok := reg.LookupDisplay(reflect.TypeOf(T1{})); ok {
+		t.Fatalf("LookupDisplay after Reset: want miss")
+	}
+}
+
+func TestRegisterDisplay_Errors(t *testing.T) {
+	reg := registry.New(config.DefaultConfig()).(apis.DisplayRegistry)
+
+	if err := reg.RegisterDisplay(reflect.TypeOf(T1{}), "domain.user", ""); err != registry.ErrEmptyName {
+		t.Fatalf("empty display: want ErrEmptyName, got %v", err)
+	}
+	_ = reg.Register(reflect.TypeOf(T1{}), "domain.user")
+	if err := reg.RegisterDisplay(reflect.TypeOf(T1{}), "other", "Other"); err != registry.ErrConflictingRegistration {
+		t.Fatalf("conflicting canonical: want ErrConflictingRegistration, got %v", err)
+	}
+	if _, ok := reg.LookupDisplay(reflect.TypeOf(T1{})); ok {
+		t.Fatalf("display must not be stored when canonical registration fails")
+	}
+}
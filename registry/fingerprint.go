@@ -0,0 +1,61 @@
+/*
+   Copyright 2025 The DIRPX Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package registry
+
+import (
+	"hash/fnv"
+	"sort"
+
+	"dirpx.dev/rfx/apis"
+)
+
+// Fingerprint returns a stable FNV-1a hash over the sorted
+// (type package path, type name, registered name) tuples of reg.
+// It does not depend on Entries() iteration order, so equal registries
+// yield equal fingerprints across processes and builds.
+// A nil or empty registry has the fingerprint of no tuples.
+func Fingerprint(reg apis.Registry) uint64 {
+	type tuple struct{ pkg, typ, name string }
+
+	var tuples []tuple
+	if reg != nil {
+		for _, e := range reg.Entries() {
+			tuples = append(tuples, tuple{e.Type.PkgPath(), e.Type.Name(), e.Name})
+		}
+	}
+	sort.Slice(tuples, func(i, j int) bool {
+		a, b := tuples[i], tuples[j]
+		if a.pkg != b.pkg {
+			return a.pkg < b.pkg
+		}
+		if a.typ != b.typ {
+			return a.typ < b.typ
+		}
+		return a.name < b.name
+	})
+
+	h := fnv.New64a()
+	for _, t := range tuples {
+		h.Write([]byte(t.pkg))
+		h.Write([]byte{0})
+		h.Write([]byte(t.typ))
+		h.Write([]byte{0})
+		h.Write([]byte(t.name))
+		h.Write([]byte{0})
+	}
+	return h.Sum64()
+}
@@ -0,0 +1,60 @@
+/*
+   Copyright 2025 The DIRPX Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package registry_test
+
+import (
+	"reflect"
+	"testing"
+
+	"dirpx.dev/rfx/config"
+	"dirpx.dev/rfx/registry"
+)
+
+func TestFingerprint(t *testing.T) {
+	a := registry.New(config.DefaultConfig())
+	_ = a.Register(reflect.TypeOf(T0{}), "t0")
+	_ = a.Register(reflect.TypeOf(T1{}), "t1")
+
+	// Same content, different registration order and implementation.
+	b := registry.NewSharded(config.DefaultConfig(), 4)
+	_ = b.Register(reflect.TypeOf(T1{}), "t1")
+	_ = b.Register(reflect.TypeOf(T0{}), "t0")
+
+	if fa, fb := registry.Fingerprint(a), registry.Fingerprint(b); fa != fb {
+		t.Fatalf("equal registries: %x != %x", fa, fb)
+	}
+	if registry.Fingerprint(a) != registry.Fingerprint(a) {
+		t.Fatal("Fingerprint is not deterministic")
+	}
+
+	before := registry.Fingerprint(a)
+	_ = a.Register(reflect.TypeOf(T2{}), "t2")
+	if registry.Fingerprint(a) == before {
+		t.Fatal("adding an entry must change the fingerprint")
+	}
+
+	c := registry.New(config.DefaultConfig())
+	_ = c.Register(reflect.TypeOf(T0{}), "t0")
+	_ = c.Register(reflect.TypeOf(T1{}), "t1.renamed")
+	if registry.Fingerprint(c) == registry.Fingerprint(b) {
+		t.Fatal("renaming an entry must change the fingerprint")
+	}
+
+	if registry.Fingerprint(nil) != registry.Fingerprint(registry.New(config.DefaultConfig())) {
+		t.Fatal("nil and empty registries should share a fingerprint")
+	}
+}
@@ -0,0 +1,60 @@
+/*
+   Copyright 2025 The DIRPX Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package registry
+
+import (
+	"bytes"
+	"encoding/gob"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"dirpx.dev/rfx/apis"
+	"dirpx.dev/rfx/config"
+)
+
+// GobEncode serializes the entries of reg as gob-encoded SerializableEntry
+// values, sorted by name so equal registries encode identically.
+func GobEncode(reg apis.Registry) ([]byte, error) {
+	entries := ToSerializable(reg.Entries())
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name < entries[j].Name })
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(entries); err != nil {
+		return nil, fmt.Errorf("rfx(registry): gob encode: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// GobDecode rebuilds a registry (created by New with the default
+// configuration) from data produced by GobEncode. Like FromSerializable, it
+// uses resolve to map a package path and type name back to a reflect.Type
+// and skips entries resolve cannot map.
+func GobDecode(data []byte, resolve func(pkgPath, typeName string) (reflect.Type, bool)) (apis.Registry, error) {
+	var entries []SerializableEntry
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&entries); err != nil {
+		return nil, fmt.Errorf("rfx(registry): gob decode: %w", err)
+	}
+
+	reg := New(config.DefaultConfig())
+	for _, e := range FromSerializable(entries, resolve) {
+		if err := reg.Register(e.Type, e.Name); err != nil {
+			return nil, fmt.Errorf("rfx(registry): gob decode: register %v as %q: %w", e.Type, e.Name, err)
+		}
+	}
+	return reg, nil
+}
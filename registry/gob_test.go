@@ -0,0 +1,58 @@
+/*
+   Copyright 2025 The DIRPX Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package registry_test
+
+import (
+	"reflect"
+	"testing"
+
+	"dirpx.dev/rfx/config"
+	"dirpx.dev/rfx/registry"
+)
+
+func TestGob_RoundTrip(t *testing.T) {
+	src := registry.New(config.DefaultConfig())
+	_ = src.Register(reflect.TypeOf(T0{}), "t0")
+	_ = src.Register(reflect.TypeOf(T1{}), "t1")
+
+	data, err := registry.GobEncode(src)
+	if err != nil {
+		t.Fatalf("GobEncode: %v", err)
+	}
+
+	known := map[string]reflect.Type{}
+	for _, typ := range []reflect.Type{reflect.TypeOf(T0{}), reflect.TypeOf(T1{})} {
+		known[typ.PkgPath()+"."+typ.Name()] = typ
+	}
+	dst, err := registry.GobDecode(data, func(pkgPath, typeName string) (reflect.Type, bool) {
+		typ, ok := known[pkgPath+"."+typeName]
+		return typ, ok
+	})
+	if err != nil {
+		t.Fatalf("GobDecode: %v", err)
+	}
+	if d := registry.Diff(src, dst); !d.Empty() {
+		t.Fatalf("round trip differs: %+v", d)
+	}
+}
+
+func TestGobDecode_InvalidData(t *testing.T) {
+	none := func(string, string) (reflect.Type, bool) { return nil, false }
+	if _, err := registry.GobDecode([]byte("not gob"), none); err == nil {
+		t.Fatal("GobDecode(garbage): want error")
+	}
+}
@@ -0,0 +1,120 @@
+/*
+   Copyright 2025 The DIRPX Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package registry
+
+import (
+	"reflect"
+	"sync"
+
+	"dirpx.dev/rfx/apis"
+	uref "dirpx.dev/rfx/utils/reflect"
+)
+
+// RegisterLazy associates the nearest named type of t with the name returned
+// by fn, deferring the call to the first Lookup of that type; the result is
+// then registered like Register and memoized. Until resolved, the type is
+// not reported by Entries or Count.
+//
+// fn runs at most once at a time and never again after it succeeds.
+// If fn returns an error or an empty name, that Lookup misses and the next
+// Lookup of the type calls fn again, so transient failures (such as a schema
+// file not loaded yet) recover on their own. A per-entry mutex is used
+// rather than sync.Once, which could not retry.
+//
+// It returns ErrConflictingRegistration if the type is already registered,
+// eagerly or lazily, and ErrFrozen after Freeze. A later Register of the type
+// takes precedence and drops the lazy entry, so PendingLazy no longer reports
+// it.
+func (r *registry) RegisterLazy(t reflect.Type, fn func() (string, error)) error {
+	if r.frozen.Load() {
+		return ErrFrozen
+	}
+	if t == nil {
+		return ErrNilType
+	}
+	if fn == nil {
+		return ErrEmptyName
+	}
+	b, err := uref.Normalize(t, r.cfg)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.m.Load(b); ok {
+		return ErrConflictingRegistration
+	}
+	if _, loaded := r.lazy.LoadOrStore(b, &lazyEntry{fn: fn}); loaded {
+		return ErrConflictingRegistration
+	}
+	return nil
+}
+
+// PendingLazy returns the lazy registrations whose name has not been
+// resolved yet, in unspecified order.
+func (r *registry) PendingLazy() []apis.LazyEntry {
+	var out []apis.LazyEntry
+	r.lazy.Range(func(key, value any) bool {
+		out = append(out, apis.LazyEntry{Type: key.(reflect.Type), Fn: value.(*lazyEntry).fn})
+		return true
+	})
+	return out
+}
+
+// lazyEntry is a pending name computed by fn on first lookup.
+type lazyEntry struct {
+	// mu serializes calls to fn and guards name and done.
+	mu sync.Mutex
+	// fn computes the name.
+	fn func() (string, error)
+	// name is the result of the first successful call to fn.
+	name string
+	// done reports whether fn has succeeded.
+	done bool
+}
+
+// resolve calls e.fn for the normalized type t unless a concurrent caller
+// already resolved it, and registers the result in r. The entry's lock only
+// covers fn: the caller that ran fn registers the name, and with it notifies
+// subscribers, after releasing the lock, so subscribers may resolve other
+// lazy entries whose fn depends on t.
+func (e *lazyEntry) resolve(r *registry, t reflect.Type) (string, bool) {
+	e.mu.Lock()
+	if e.done {
+		name := e.name
+		e.mu.Unlock()
+		return name, true
+	}
+	name, err := e.fn()
+	if err != nil || name == "" {
+		e.mu.Unlock()
+		return "", false
+	}
+	e.name, e.done = name, true
+	e.mu.Unlock()
+
+	// register drops the entry, unless an eager registration won the race and
+	// dropped it already; the eager name then takes precedence.
+	if err := r.register(t, name); err != nil {
+		if v, ok := r.m.Load(t); ok {
+			return v.(string), true
+		}
+		return "", false
+	}
+	return name, true
+}
@@ -0,0 +1,197 @@
+/*
+   Copyright 2025 The DIRPX Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package registry_test
+
+import (
+	"errors"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"dirpx.dev/rfx/apis"
+	"dirpx.dev/rfx/config"
+	"dirpx.dev/rfx/registry"
+)
+
+func TestRegisterLazy_DeferredAndMemoized(t *testing.T) {
+	reg := registry.New(config.DefaultConfig())
+	var calls atomic.Int32
+	err := reg.(apis.LazyRegistry).RegisterLazy(reflect.TypeOf(T0{}), func() (string, error) {
+		calls.Add(1)
+		return "lazy.t0", nil
+	})
+	if err != nil {
+		t.Fatalf("RegisterLazy: %v", err)
+	}
+	if calls.Load() != 0 || reg.Count() != 0 {
+		t.Fatalf("fn ran or entry counted before lookup: calls=%d count=%d", calls.Load(), reg.Count())
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if got, ok := reg.Lookup(reflect.TypeOf(&T0{})); !ok || got != "lazy.t0" {
+				t.Errorf("Lookup = (%q,%v), want (lazy.t0,true)", got, ok)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if n := calls.Load(); n != 1 {
+		t.Fatalf("fn called %d times, want 1", n)
+	}
+	if reg.Count() != 1 {
+		t.Fatalf("Count() = %d, want 1", reg.Count())
+	}
+}
+
+func TestRegisterLazy_ErrorRetries(t *testing.T) {
+	reg := registry.New(config.DefaultConfig())
+	ready := false
+	_ = reg.(apis.LazyRegistry).RegisterLazy(reflect.TypeOf(T1{}), func() (string, error) {
+		if !ready {
+			return "", errors.New("schema not loaded")
+		}
+		return "lazy.t1", nil
+	})
+
+	if _, ok := reg.Lookup(reflect.TypeOf(T1{})); ok {
+		t.Fatal("Lookup before ready: want miss")
+	}
+	ready = true
+	if got, ok := reg.Lookup(reflect.TypeOf(T1{})); !ok || got != "lazy.t1" {
+		t.Fatalf("Lookup after ready = (%q,%v), want (lazy.t1,true)", got, ok)
+	}
+}
+
+func TestRegisterLazy_Conflicts(t *testing.T) {
+	reg := registry.New(config.DefaultConfig())
+	lazy := reg.(apis.LazyRegistry)
+	fn := func() (string, error) { return "x", nil }
+
+	_ = reg.Register(reflect.TypeOf(T0{}), "t0")
+	if err := lazy.RegisterLazy(reflect.TypeOf(T0{}), fn); !errors.Is(err, registry.ErrConflictingRegistration) {
+		t.Fatalf("over eager: want ErrConflictingRegistration, got %v", err)
+	}
+	_ = lazy.RegisterLazy(reflect.TypeOf(T1{}), fn)
+	if err := lazy.RegisterLazy(reflect.TypeOf(T1{}), fn); !errors.Is(err, registry.ErrConflictingRegistration) {
+		t.Fatalf("over lazy: want ErrConflictingRegistration, got %v", err)
+	}
+}
+
+func TestRegisterLazy_PendingAndUnregister(t *testing.T) {
+	reg := registry.New(config.DefaultConfig())
+	lazy := reg.(apis.LazyRegistry)
+	fn := func() (string, error) { return "lazy.t2", nil }
+	if err := lazy.RegisterLazy(reflect.TypeOf(T2{}), fn); err != nil {
+		t.Fatalf("RegisterLazy: %v", err)
+	}
+	if p := lazy.PendingLazy(); len(p) != 1 || p[0].Type != reflect.TypeOf(T2{}) {
+		t.Fatalf("PendingLazy = %v, want one entry for T2", p)
+	}
+
+	var events []apis.RegistryEvent
+	reg.(apis.Subscriber).Subscribe(func(ev apis.RegistryEvent) { events = append(events, ev) })
+
+	if !reg.(apis.Unregisterer).Unregister(reflect.TypeOf(T2{})) {
+		t.Fatal("Unregister of a pending lazy entry should report true")
+	}
+	if len(events) != 1 || events[0].Kind != apis.EventUnregistered || events[0].Type != reflect.TypeOf(T2{}) {
+		t.Fatalf("events = %+v, want one EventUnregistered for T2", events)
+	}
+	if len(lazy.PendingLazy()) != 0 {
+		t.Fatal("PendingLazy should be empty after Unregister")
+	}
+	if _, ok := reg.Lookup(reflect.TypeOf(T2{})); ok {
+		t.Fatal("Lookup should miss after Unregister")
+	}
+}
+
+func TestRegisterLazy_EagerRegistrationDropsEntry(t *testing.T) {
+	reg := registry.New(config.DefaultConfig())
+	lazy := reg.(apis.LazyRegistry)
+	t0, t1 := reflect.TypeOf(T0{}), reflect.TypeOf(T1{})
+
+	// Register before the first Lookup.
+	_ = lazy.RegisterLazy(t0, func() (string, error) { return "lazy.t0", nil })
+	if err := reg.Register(t0, "eager.t0"); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	// Register while fn runs, so the eager name wins the race.
+	_ = lazy.RegisterLazy(t1, func() (string, error) {
+		_ = reg.Register(t1, "eager.t1")
+		return "lazy.t1", nil
+	})
+
+	if got, ok := reg.Lookup(t0); !ok || got != "eager.t0" {
+		t.Fatalf("Lookup(T0) = (%q,%v), want (eager.t0,true)", got, ok)
+	}
+	if got, ok := reg.Lookup(t1); !ok || got != "eager.t1" {
+		t.Fatalf("Lookup(T1) = (%q,%v), want (eager.t1,true)", got, ok)
+	}
+	if p := lazy.PendingLazy(); len(p) != 0 {
+		t.Fatalf("PendingLazy = %v, want none", p)
+	}
+}
+
+func TestRegisterLazy_SubscriberDoesNotDeadlock(t *testing.T) {
+	reg := registry.New(config.DefaultConfig())
+	lazy := reg.(apis.LazyRegistry)
+	t0, t1 := reflect.TypeOf(T0{}), reflect.TypeOf(T1{})
+
+	// t1's fn looks t0 up while t0 is being resolved, and the subscriber
+	// reacting to t0 looks t1 up: holding t0's entry lock while notifying
+	// would deadlock the two lookups.
+	t0Started, t1Started := make(chan struct{}), make(chan struct{})
+	_ = lazy.RegisterLazy(t0, func() (string, error) {
+		close(t0Started)
+		<-t1Started
+		return "lazy.t0", nil
+	})
+	_ = lazy.RegisterLazy(t1, func() (string, error) {
+		close(t1Started)
+		reg.Lookup(t0)
+		return "lazy.t1", nil
+	})
+	reg.(apis.Subscriber).Subscribe(func(ev apis.RegistryEvent) {
+		if ev.Type == t0 {
+			reg.Lookup(t1)
+		}
+	})
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() { defer wg.Done(); reg.Lookup(t0) }()
+	go func() { defer wg.Done(); <-t0Started; reg.Lookup(t1) }()
+
+	done := make(chan struct{})
+	go func() { wg.Wait(); close(done) }()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("lazy resolution deadlocked with a subscriber")
+	}
+	for _, tt := range []reflect.Type{t0, t1} {
+		if _, ok := reg.Lookup(tt); !ok {
+			t.Fatalf("Lookup(%v) missed after resolution", tt)
+		}
+	}
+}
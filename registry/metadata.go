@@ -0,0 +1,56 @@
+/*
+   Copyright 2025 The DIRPX Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package registry
+
+import (
+	"reflect"
+
+	"dirpx.dev/rfx/apis"
+	uref "dirpx.dev/rfx/utils/reflect"
+)
+
+var _ apis.MetadataRegistry = (*registry)(nil)
+
+// RegisterDescribed registers t under d.Name and associates d with it.
+// A later call for the same type and name replaces the description.
+func (r *registry) RegisterDescribed(t reflect.Type, d apis.Description) error {
+	if err := r.Register(t, d.Name); err != nil {
+		return err
+	}
+	// Register succeeded, so normalization cannot fail here.
+	b, _ := uref.Normalize(t, r.cfg)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.meta.Store(b, d)
+	return nil
+}
+
+// LookupDescription returns the description registered for t if present.
+func (r *registry) LookupDescription(t reflect.Type) (apis.Description, bool) {
+	if t == nil {
+		return apis.Description{}, false
+	}
+	nt, err := uref.Normalize(t, r.cfg)
+	if err != nil {
+		return apis.Description{}, false
+	}
+	if v, ok := r.meta.Load(nt); ok {
+		return v.(apis.Description), true
+	}
+	return apis.Description{}, false
+}
@@ -0,0 +1,49 @@
+/*
+   Copyright 2025 The DIRPX Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package registry_test
+
+import (
+	"reflect"
+	"testing"
+
+	"dirpx.dev/rfx/apis"
+	"dirpx.dev/rfx/config"
+	"dirpx.dev/rfx/registry"
+)
+
+func TestRegisterDescribed_LookupAndClear(t *testing.T) {
+	reg := registry.New(config.DefaultConfig()).(apis.MetadataRegistry)
+	d := apis.Description{Name: "domain.user", Version: "v1", Description: "a user"}
+	if err := reg.RegisterDescribed(reflect.TypeOf(&T1{}), d); err != nil {
+		t.Fatalf("RegisterDescribed: %v", err)
+	}
+	if got, ok := reg.Lookup(reflect.TypeOf(T1{})); !ok || got != "domain.user" {
+		t.Fatalf("Lookup = (%q, %v), want (domain.user, true)", got, ok)
+	}
+	if got, ok := reg.LookupDescription(reflect.TypeOf([]T1{})); !ok || got != d {
+		t.Fatalf("LookupDescription = (%+v, %v), want (%+v, true)", got, ok, d)
+	}
+
+	if err := reg.RegisterDescribed(reflect.TypeOf(T2{}), apis.Description{}); err == nil {
+		t.Fatal("RegisterDescribed without a name should fail")
+	}
+
+	reg.(apis.Unregisterer).Unregister(reflect.TypeOf(T1{}))
+	if _, ok := reg.LookupDescription(reflect.TypeOf(T1{})); ok {
+		t.Fatal("description should be dropped by Unregister")
+	}
+}
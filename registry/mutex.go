@@ -0,0 +1,205 @@
+/*
+   Copyright 2025 The DIRPX Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package registry
+
+import (
+	"reflect"
+	"sort"
+	"sync"
+	"sync/atomic"
+
+	"dirpx.dev/rfx/apis"
+	"dirpx.dev/rfx/config"
+	uref "dirpx.dev/rfx/utils/reflect"
+)
+
+// NewMutexBacked constructs a Registry backed by a plain map under a
+// sync.RWMutex. It behaves exactly like New (same normalization, errors,
+// idempotency, conflict and generic-instantiation semantics) but allocates
+// far less while registering, which suits workloads that register heavily at
+// startup. Highly parallel reads contend on the RWMutex, where New's
+// sync.Map is faster; run BenchmarkRegistryPhases_* to choose for a workload.
+//
+// capacity pre-sizes the maps for the expected number of entries, so a
+// startup burst of that size does not rehash; capacity <= 0 gives no hint.
+// Reset keeps the hint.
+//
+// Only apis.Snapshotter and apis.Freezer are implemented. Display names,
+// descriptions, lazy entries, subscriptions and Unregister are not
+// supported: wrapped in a Wrapper, writes to them fail with
+// errors.ErrUnsupported. Use New when any of them is needed.
+func NewMutexBacked(cfg apis.Config, capacity int) apis.Registry {
+	if cfg.MaxUnwrap < 0 {
+		cfg.MaxUnwrap = config.DefaultMaxUnwrap
+	}
+	if capacity < 0 {
+		capacity = 0
+	}
+	r := &mutexRegistry{cfg: cfg, capacity: capacity}
+	r.makeMaps()
+	return r
+}
+
+// Ensure mutexRegistry implements apis.Registry, apis.Snapshotter and
+// apis.Freezer.
+var (
+	_ apis.Registry    = (*mutexRegistry)(nil)
+	_ apis.Snapshotter = (*mutexRegistry)(nil)
+	_ apis.Freezer     = (*mutexRegistry)(nil)
+)
+
+// mutexRegistry is a Registry backed by a map guarded by an RWMutex.
+type mutexRegistry struct {
+	// cfg is the configuration used for type normalization.
+	cfg apis.Config
+	// mu guards m, seq, nextSeq and generic.
+	mu sync.RWMutex
+	// m maps reflect.Type to registered name.
+	m map[reflect.Type]string
+	// seq maps reflect.Type to its registration sequence number.
+	seq map[reflect.Type]uint64
+	// nextSeq is the sequence number of the next registration.
+	nextSeq uint64
+	// generic maps a generic definition to the first instantiation's name.
+	generic map[string]string
+	// capacity is the size hint for m and seq.
+	capacity int
+	// frozen is set by Freeze and makes every write fail.
+	frozen atomic.Bool
+}
+
+// Register associates the nearest named type of t with the given name.
+// It is idempotent for the same (type,name) pair.
+// After Freeze it returns ErrFrozen.
+func (r *mutexRegistry) Register(t reflect.Type, name string) error {
+	if r.frozen.Load() {
+		return ErrFrozen
+	}
+	if t == nil {
+		return ErrNilType
+	}
+	if name == "" {
+		return ErrEmptyName
+	}
+	b, err := uref.Normalize(t, r.cfg)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if old, ok := r.m[b]; ok {
+		if old == name {
+			return nil
+		}
+		return ErrConflictingRegistration
+	}
+	r.m[b] = name
+	r.seq[b] = r.nextSeq
+	r.nextSeq++
+	if g := uref.GenericBaseName(b); g != "" {
+		if _, ok := r.generic[g]; !ok {
+			r.generic[g] = name
+		}
+	}
+	return nil
+}
+
+// Lookup returns a name for a type if present.
+func (r *mutexRegistry) Lookup(t reflect.Type) (string, bool) {
+	if t == nil {
+		return "", false
+	}
+	b, err := uref.Normalize(t, r.cfg)
+	if err != nil {
+		return "", false
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if name, ok := r.m[b]; ok {
+		return name, true
+	}
+	if g := uref.GenericBaseName(b); g != "" {
+		if name, ok := r.generic[g]; ok {
+			return name, true
+		}
+	}
+	return "", false
+}
+
+// Entries returns a snapshot for diagnostics/docs in registration order.
+func (r *mutexRegistry) Entries() []apis.Entry {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.entriesLocked()
+}
+
+// entriesLocked lists the entries in registration order; r.mu must be held.
+func (r *mutexRegistry) entriesLocked() []apis.Entry {
+	entries := make([]apis.Entry, 0, len(r.m))
+	for t, name := range r.m {
+		entries = append(entries, apis.Entry{Type: t, Name: name})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return r.seq[entries[i].Type] < r.seq[entries[j].Type]
+	})
+	return entries
+}
+
+// Count returns the number of registered entries.
+func (r *mutexRegistry) Count() int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return len(r.m)
+}
+
+// Reset clears all registered entries. After Freeze it does nothing.
+func (r *mutexRegistry) Reset() {
+	if r.frozen.Load() {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.makeMaps()
+}
+
+// makeMaps allocates empty maps sized by r.capacity; r.mu must be held or r
+// not yet shared.
+func (r *mutexRegistry) makeMaps() {
+	r.m = make(map[reflect.Type]string, r.capacity)
+	r.seq = make(map[reflect.Type]uint64, r.capacity)
+	r.generic = make(map[string]string)
+}
+
+// Snapshot builds an immutable view of the current entries.
+// Later registrations are not reflected.
+func (r *mutexRegistry) Snapshot() apis.RegistrySnapshot {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	entries := r.entriesLocked()
+	generic := make(map[string]string, len(r.generic))
+	for k, v := range r.generic {
+		generic[k] = v
+	}
+	return newSnapshot(r.cfg, entries, generic)
+}
+
+// Freeze makes the registry read-only for good: Register returns ErrFrozen
+// and Reset does nothing.
+func (r *mutexRegistry) Freeze() {
+	r.frozen.Store(true)
+}
@@ -0,0 +1,130 @@
+/*
+   Copyright 2025 The DIRPX Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package registry_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"dirpx.dev/rfx/apis"
+	"dirpx.dev/rfx/config"
+	"dirpx.dev/rfx/registry"
+)
+
+// TestMutexBacked_MatchesDefault runs the same operations against both
+// implementations and expects identical results.
+func TestMutexBacked_MatchesDefault(t *testing.T) {
+	regs := []apis.Registry{
+		registry.New(config.DefaultConfig()),
+		registry.NewMutexBacked(config.DefaultConfig(), 0),
+	}
+	for _, reg := range regs {
+		if err := reg.Register(reflect.TypeOf(T0{}), "t0"); err != nil {
+			t.Fatalf("%T: Register: %v", reg, err)
+		}
+		if err := reg.Register(reflect.TypeOf(&T0{}), "t0"); err != nil {
+			t.Fatalf("%T: idempotent Register: %v", reg, err)
+		}
+		if err := reg.Register(reflect.TypeOf(T0{}), "other"); !errors.Is(err, registry.ErrConflictingRegistration) {
+			t.Fatalf("%T: want ErrConflictingRegistration, got %v", reg, err)
+		}
+		if err := reg.Register(nil, "x"); !errors.Is(err, registry.ErrNilType) {
+			t.Fatalf("%T: want ErrNilType, got %v", reg, err)
+		}
+		if err := reg.Register(reflect.TypeOf(T1{}), ""); !errors.Is(err, registry.ErrEmptyName) {
+			t.Fatalf("%T: want ErrEmptyName, got %v", reg, err)
+		}
+		if err := reg.Register(reflect.TypeOf(struct{}{}), "anon"); err == nil {
+			t.Fatalf("%T: anonymous type should fail", reg)
+		}
+		_ = reg.Register(reflect.TypeOf(Gen[int]{}), "gen")
+		if got, ok := reg.Lookup(reflect.TypeOf(Gen[string]{})); !ok || got != "gen" {
+			t.Fatalf("%T: generic fallback = (%q,%v)", reg, got, ok)
+		}
+		if got, ok := reg.Lookup(reflect.TypeOf([]*T0{})); !ok || got != "t0" {
+			t.Fatalf("%T: Lookup([]*T0) = (%q,%v)", reg, got, ok)
+		}
+		if reg.Count() != 2 || len(reg.Entries()) != 2 {
+			t.Fatalf("%T: Count=%d Entries=%d, want 2", reg, reg.Count(), len(reg.Entries()))
+		}
+		reg.Reset()
+		if reg.Count() != 0 {
+			t.Fatalf("%T: Count after Reset = %d", reg, reg.Count())
+		}
+	}
+}
+
+func TestMutexBacked_CapacityAndUnsupported(t *testing.T) {
+	reg := registry.NewMutexBacked(config.DefaultConfig(), 64)
+	for i := 0; i < 2; i++ {
+		for _, tt := range shardTestTypes {
+			if err := reg.Register(tt, tt.Name()); err != nil {
+				t.Fatalf("Register(%v): %v", tt, err)
+			}
+		}
+		if reg.Count() != len(shardTestTypes) {
+			t.Fatalf("Count = %d, want %d", reg.Count(), len(shardTestTypes))
+		}
+		reg.Reset()
+	}
+
+	w := registry.Wrapper{Registry: reg, Config: config.DefaultConfig()}
+	if err := w.RegisterDisplay(reflect.TypeOf(T0{}), "t0", "T0"); !errors.Is(err, errors.ErrUnsupported) {
+		t.Fatalf("RegisterDisplay: want ErrUnsupported, got %v", err)
+	}
+	if err := w.RegisterLazy(reflect.TypeOf(T0{}), func() (string, error) { return "t0", nil }); !errors.Is(err, errors.ErrUnsupported) {
+		t.Fatalf("RegisterLazy: want ErrUnsupported, got %v", err)
+	}
+}
+
+// benchmarkPhases registers every test type, then performs lookups only,
+// mirroring a register-at-startup, read-forever workload.
+func benchmarkPhases(b *testing.B, newReg func() apis.Registry) {
+	b.Run("register", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			reg := newReg()
+			for _, tt := range shardTestTypes {
+				_ = reg.Register(tt, tt.Name())
+			}
+		}
+	})
+	b.Run("read", func(b *testing.B) {
+		reg := newReg()
+		for _, tt := range shardTestTypes {
+			_ = reg.Register(tt, tt.Name())
+		}
+		b.ReportAllocs()
+		b.ResetTimer()
+		b.RunParallel(func(pb *testing.PB) {
+			i := 0
+			for pb.Next() {
+				_, _ = reg.Lookup(shardTestTypes[i%len(shardTestTypes)])
+				i++
+			}
+		})
+	})
+}
+
+func BenchmarkRegistryPhases_SyncMap(b *testing.B) {
+	benchmarkPhases(b, func() apis.Registry { return registry.New(config.DefaultConfig()) })
+}
+
+func BenchmarkRegistryPhases_MutexBacked(b *testing.B) {
+	benchmarkPhases(b, func() apis.Registry { return registry.NewMutexBacked(config.DefaultConfig(), len(shardTestTypes)) })
+}
@@ -0,0 +1,94 @@
+/*
+   Copyright 2025 The DIRPX Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package registry
+
+import (
+	"reflect"
+
+	"dirpx.dev/rfx/apis"
+	"dirpx.dev/rfx/config"
+)
+
+// Namespaced returns an apis.Registry that stores every name registered
+// through it as prefix + "." + name in inner, so a library can register
+// "entry" and have it surface as "mylib.entry".
+// Lookup, Entries, Count and Reset are delegated to inner and therefore
+// observe the prefixed names. The optional registry interfaces are forwarded
+// like Wrapper does, with names written through the display, metadata and lazy
+// extensions prefixed as well. An empty prefix leaves names unchanged.
+// A nil inner is replaced by New(config.DefaultConfig()).
+func Namespaced(inner apis.Registry, prefix string) apis.Registry {
+	if inner == nil {
+		inner = New(config.DefaultConfig())
+	}
+	return &namespaced{Wrapper: Wrapper{Registry: inner, Config: config.DefaultConfig()}, prefix: prefix}
+}
+
+// namespaced prefixes names on registration and delegates storage to the
+// wrapped registry.
+type namespaced struct {
+	Wrapper
+	// prefix is prepended (with a dot) to every registered name.
+	prefix string
+}
+
+// Register stores t under the prefixed name in the inner registry.
+func (r *namespaced) Register(t reflect.Type, name string) error {
+	if name == "" {
+		return ErrEmptyName
+	}
+	return r.Registry.Register(t, r.prefixed(name))
+}
+
+// RegisterDisplay stores t under the prefixed canonical name with display.
+func (r *namespaced) RegisterDisplay(t reflect.Type, canonical, display string) error {
+	if canonical == "" {
+		return ErrEmptyName
+	}
+	return r.Wrapper.RegisterDisplay(t, r.prefixed(canonical), display)
+}
+
+// RegisterDescribed stores t under the prefixed d.Name together with d.
+func (r *namespaced) RegisterDescribed(t reflect.Type, d apis.Description) error {
+	if d.Name == "" {
+		return ErrEmptyName
+	}
+	d.Name = r.prefixed(d.Name)
+	return r.Wrapper.RegisterDescribed(t, d)
+}
+
+// RegisterLazy registers fn with inner, prefixing the name it computes.
+func (r *namespaced) RegisterLazy(t reflect.Type, fn func() (string, error)) error {
+	if fn == nil {
+		return ErrEmptyName
+	}
+	return r.Wrapper.RegisterLazy(t, func() (string, error) {
+		name, err := fn()
+		if err != nil || name == "" {
+			return name, err
+		}
+		return r.prefixed(name), nil
+	})
+}
+
+// prefixed returns name under the namespace prefix.
+func (r *namespaced) prefixed(name string) string {
+	if r.prefix == "" {
+		return name
+	}
+	return r.prefix + "." + name
+}
@@ -0,0 +1,124 @@
+/*
+   Copyright 2025 The DIRPX Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package registry_test
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	"dirpx.dev/rfx/apis"
+	"dirpx.dev/rfx/config"
+	"dirpx.dev/rfx/registry"
+)
+
+func TestNamespaced_RegisterAndLookup(t *testing.T) {
+	inner := registry.New(config.DefaultConfig())
+	reg := registry.Namespaced(inner, "mylib")
+
+	if err := reg.Register(reflect.TypeOf(&T1{}), "entry"); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	if name, ok := reg.Lookup(reflect.TypeOf(T1{})); !ok || name != "mylib.entry" {
+		t.Fatalf("Lookup: got (%q,%v), want (mylib.entry,true)", name, ok)
+	}
+	// Storage is delegated: the inner registry sees the prefixed name.
+	if name, ok := inner.Lookup(reflect.TypeOf(T1{})); !ok || name != "mylib.entry" {
+		t.Fatalf("inner Lookup: got (%q,%v), want (mylib.entry,true)", name, ok)
+	}
+	// Idempotency and conflicts apply to the prefixed names.
+	if err := reg.Register(reflect.TypeOf(T1{}), "entry"); err != nil {
+		t.Fatalf("idempotent Register: %v", err)
+	}
+	if err := reg.Register(reflect.TypeOf(T1{}), "other"); err != registry.ErrConflictingRegistration {
+		t.Fatalf("conflict: want ErrConflictingRegistration, got %v", err)
+	}
+	if err := reg.Register(reflect.TypeOf(T2{}), ""); err != registry.ErrEmptyName {
+		t.Fatalf("empty name: want ErrEmptyName, got %v", err)
+	}
+}
+
+func TestNamespaced_Entries(t *testing.T) {
+	reg := registry.Namespaced(registry.New(config.DefaultConfig()), "mylib")
+	_ = reg.Register(reflect.TypeOf(T1{}), "a")
+	_ = reg.Register(reflect.TypeOf(T2{}), "b")
+
+	var names []string
+	for _, e := range reg.Entries() {
+		names = append(names, e.Name)
+	}
+	sort.Strings(names)
+	if len(names) != 2 || names[0] != "mylib.a" || names[1] != "mylib.b" {
+		t.Fatalf("Entries names = %v, want [mylib.a mylib.b]", names)
+	}
+	if reg.Count() != 2 {
+		t.Fatalf("Count() = %d, want 2", reg.Count())
+	}
+
+	reg.Reset()
+	if reg.Count() != 0 {
+		t.Fatalf("after Reset, Count() = %d, want 0", reg.Count())
+	}
+}
+
+func TestNamespaced_EmptyPrefix(t *testing.T) {
+	reg := registry.Namespaced(nil, "")
+	_ = reg.Register(reflect.TypeOf(T1{}), "plain")
+	if name, _ := reg.Lookup(reflect.TypeOf(T1{})); name != "plain" {
+		t.Fatalf("Lookup: got %q, want %q", name, "plain")
+	}
+}
+
+func TestNamespaced_ForwardsExtensions(t *testing.T) {
+	reg := registry.Namespaced(registry.New(config.DefaultConfig()), "mylib")
+
+	var events []apis.RegistryEvent
+	unsubscribe := reg.(apis.Subscriber).Subscribe(func(ev apis.RegistryEvent) {
+		events = append(events, ev)
+	})
+	defer unsubscribe()
+
+	if err := reg.(apis.DisplayRegistry).RegisterDisplay(reflect.TypeOf(T1{}), "a", "Alpha"); err != nil {
+		t.Fatalf("RegisterDisplay: %v", err)
+	}
+	if got, _ := reg.(apis.DisplayRegistry).LookupDisplay(reflect.TypeOf(T1{})); got != "Alpha" {
+		t.Fatalf("LookupDisplay = %q, want Alpha", got)
+	}
+	if err := reg.(apis.MetadataRegistry).RegisterDescribed(reflect.TypeOf(T2{}), apis.Description{Name: "b", Category: "team"}); err != nil {
+		t.Fatalf("RegisterDescribed: %v", err)
+	}
+	if d, _ := reg.(apis.MetadataRegistry).LookupDescription(reflect.TypeOf(T2{})); d.Name != "mylib.b" || d.Category != "team" {
+		t.Fatalf("LookupDescription = %+v, want mylib.b by team", d)
+	}
+	if err := reg.(apis.LazyRegistry).RegisterLazy(reflect.TypeOf(T3{}), func() (string, error) { return "c", nil }); err != nil {
+		t.Fatalf("RegisterLazy: %v", err)
+	}
+	if got, _ := reg.Lookup(reflect.TypeOf(T3{})); got != "mylib.c" {
+		t.Fatalf("lazy Lookup = %q, want mylib.c", got)
+	}
+
+	snap := reg.(apis.Snapshotter).Snapshot()
+	if got, ok := snap.Lookup(reflect.TypeOf(T1{})); !ok || got != "mylib.a" {
+		t.Fatalf("Snapshot().Lookup = (%q,%v), want (mylib.a,true)", got, ok)
+	}
+	if !reg.(apis.Unregisterer).Unregister(reflect.TypeOf(T1{})) {
+		t.Fatal("Unregister reported false")
+	}
+	if len(events) != 4 || events[0].Name != "mylib.a" || events[3].Kind != apis.EventUnregistered {
+		t.Fatalf("events = %+v", events)
+	}
+}
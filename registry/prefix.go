@@ -0,0 +1,60 @@
+/*
+   Copyright 2025 The DIRPX Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package registry
+
+import (
+	"reflect"
+	"strings"
+
+	"dirpx.dev/rfx/apis"
+)
+
+var _ apis.PrefixQuerier = (*registry)(nil)
+
+// CountByPrefix returns the number of registered entries whose name starts
+// with prefix, without materializing them. An empty prefix counts all
+// entries, like Count. Lazy entries are only counted once resolved.
+func (r *registry) CountByPrefix(prefix string) int {
+	if prefix == "" {
+		return r.Count()
+	}
+	n := 0
+	r.m.Range(func(_, value any) bool {
+		if strings.HasPrefix(value.(string), prefix) {
+			n++
+		}
+		return true
+	})
+	return n
+}
+
+// EntriesByPrefix returns the registered entries whose name starts with
+// prefix (order is unspecified). An empty prefix returns all entries, like
+// Entries.
+func (r *registry) EntriesByPrefix(prefix string) []apis.Entry {
+	if prefix == "" {
+		return r.Entries()
+	}
+	var entries []apis.Entry
+	r.m.Range(func(key, value any) bool {
+		if name := value.(string); strings.HasPrefix(name, prefix) {
+			entries = append(entries, apis.Entry{Type: key.(reflect.Type), Name: name})
+		}
+		return true
+	})
+	return entries
+}
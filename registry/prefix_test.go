@@ -0,0 +1,65 @@
+/*
+   Copyright 2025 The DIRPX Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package registry_test
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	"dirpx.dev/rfx/apis"
+	"dirpx.dev/rfx/config"
+	"dirpx.dev/rfx/registry"
+)
+
+func TestPrefixQuerier(t *testing.T) {
+	reg := registry.New(config.DefaultConfig())
+	for typ, name := range map[reflect.Type]string{
+		reflect.TypeOf(T0{}): "billing.invoice",
+		reflect.TypeOf(T1{}): "billing.payment",
+		reflect.TypeOf(T2{}): "billingx.other",
+		reflect.TypeOf(T3{}): "auth.user",
+	} {
+		if err := reg.Register(typ, name); err != nil {
+			t.Fatal(err)
+		}
+	}
+	pq := reg.(apis.PrefixQuerier)
+
+	tests := []struct {
+		prefix string
+		want   []string
+	}{
+		{"billing.", []string{"billing.invoice", "billing.payment"}},
+		{"billing", []string{"billing.invoice", "billing.payment", "billingx.other"}},
+		{"", []string{"auth.user", "billing.invoice", "billing.payment", "billingx.other"}},
+		{"missing.", nil},
+	}
+	for _, tt := range tests {
+		if got := pq.CountByPrefix(tt.prefix); got != len(tt.want) {
+			t.Errorf("CountByPrefix(%q) = %d, want %d", tt.prefix, got, len(tt.want))
+		}
+		var names []string
+		for _, e := range pq.EntriesByPrefix(tt.prefix) {
+			names = append(names, e.Name)
+		}
+		sort.Strings(names)
+		if !reflect.DeepEqual(names, tt.want) {
+			t.Errorf("EntriesByPrefix(%q) = %v, want %v", tt.prefix, names, tt.want)
+		}
+	}
+}
@@ -18,8 +18,11 @@ package registry
 
 import (
 	"errors"
+	"math"
 	"reflect"
+	"sort"
 	"sync"
+	"sync/atomic"
 
 	"dirpx.dev/rfx/apis"
 	"dirpx.dev/rfx/config"
@@ -34,17 +37,32 @@ var (
 	// ErrConflictingRegistration indicates an attempt to re-register
 	// a type with a different name.
 	ErrConflictingRegistration = errors.New("rfx(registry): conflicting type registration")
+	// ErrFrozen is returned by writes to a registry after Freeze.
+	ErrFrozen = errors.New("rfx(registry): registry is frozen")
 )
 
 // New constructs a Registry that normalizes types according to cfg.
-// Only MaxUnwrap and MapPreferElem are used here (IncludeBuiltins is irrelevant).
+// Only the fields read by uref.Normalize are used here (MaxUnwrap,
+// MapPreferElem, NormalizeOutermost, UnwrapFinalNamed and the unsafe-kind
+// settings); naming options such as IncludeBuiltins are irrelevant.
+// A negative MaxUnwrap selects the default depth; zero unwraps nothing.
 func New(cfg apis.Config) apis.Registry {
-	if cfg.MaxUnwrap <= 0 {
+	if cfg.MaxUnwrap < 0 {
 		cfg.MaxUnwrap = config.DefaultMaxUnwrap
 	}
 	return &registry{cfg: cfg}
 }
 
+// Ensure registry implements apis.DisplayRegistry, apis.Subscriber,
+// apis.Unregisterer, apis.LazyRegistry and apis.Freezer.
+var (
+	_ apis.DisplayRegistry = (*registry)(nil)
+	_ apis.Subscriber      = (*registry)(nil)
+	_ apis.Unregisterer    = (*registry)(nil)
+	_ apis.LazyRegistry    = (*registry)(nil)
+	_ apis.Freezer         = (*registry)(nil)
+)
+
 // registry is a simple Registry implementation backed by sync.Map.
 type registry struct {
 	// cfg is the configuration used for type normalization.
@@ -53,13 +71,53 @@ type registry struct {
 	mu sync.Mutex
 	// m maps reflect.Type to registered name.
 	m sync.Map // map[reflect.Type]string
+	// generic maps a generic definition ("pkgpath.G") to the name of the first
+	// registered instantiation, so any G[...] resolves to it.
+	generic sync.Map // map[string]string
+	// seq maps reflect.Type to its registration sequence number, which orders
+	// Entries and picks the next generic fallback after an Unregister.
+	seq sync.Map // map[reflect.Type]uint64
+	// nextSeq is the sequence number of the next registration; guarded by mu.
+	nextSeq uint64
+	// lazy maps reflect.Type to a pending *lazyEntry.
+	lazy sync.Map // map[reflect.Type]*lazyEntry
+	// display maps reflect.Type to its display name.
+	display sync.Map // map[reflect.Type]string
+	// meta maps reflect.Type to its apis.Description.
+	meta sync.Map // map[reflect.Type]apis.Description
 	// count tracks the number of registered entries.
 	count int
+	// frozen is set by Freeze and makes every write fail.
+	frozen atomic.Bool
+
+	// subMu guards subs and nextSub.
+	subMu sync.Mutex
+	// subs holds the active subscribers by id.
+	subs map[uint64]func(apis.RegistryEvent)
+	// nextSub is the id of the next subscriber.
+	nextSub uint64
 }
 
 // Register associates the nearest named type of t with the given name.
 // It is idempotent for the same (type,name) pair.
+//
+// Registering an instantiation of a generic type (e.g. G[int]) also covers
+// every other instantiation (G[string], ...) in Lookup, unless that
+// instantiation is registered explicitly. The first registered instantiation
+// of a generic definition provides the shared name. Entries and Count only
+// report explicit registrations.
+//
+// After Freeze it returns ErrFrozen.
 func (r *registry) Register(t reflect.Type, name string) error {
+	if r.frozen.Load() {
+		return ErrFrozen
+	}
+	return r.register(t, name)
+}
+
+// register implements Register without the frozen check, so pending lazy
+// entries still resolve after Freeze.
+func (r *registry) register(t reflect.Type, name string) error {
 	// Validate inputs early.
 	if t == nil {
 		return ErrNilType
@@ -84,21 +142,126 @@ func (r *registry) Register(t reflect.Type, name string) error {
 
 	// Write path: guard with a mutex to keep counter consistent and avoid ABA.
 	r.mu.Lock()
-	defer r.mu.Unlock()
 
 	// Re-check under lock in case another goroutine stored meanwhile.
 	if old, ok := r.m.Load(b); ok {
+		r.mu.Unlock()
 		if old.(string) == name {
 			return nil
 		}
 		return ErrConflictingRegistration
 	}
 
+	r.seq.Store(b, r.nextSeq)
+	r.nextSeq++
 	r.m.Store(b, name)
+	r.lazy.Delete(b) // an eager registration supersedes a pending lazy one
 	r.count++
+	if g := uref.GenericBaseName(b); g != "" {
+		r.generic.LoadOrStore(g, name)
+	}
+	r.mu.Unlock()
+
+	r.notify(apis.RegistryEvent{Kind: apis.EventRegistered, Type: b, Name: name})
 	return nil
 }
 
+// Unregister removes the association for the nearest named type of t and
+// reports whether one existed, eager or lazy. If t provided the shared name
+// of its generic definition, the earliest registered remaining instantiation
+// takes over, if any. Dropping a lazy entry that was never resolved reports an
+// EventUnregistered with an empty Name. After Freeze it does nothing and
+// reports false.
+func (r *registry) Unregister(t reflect.Type) bool {
+	if t == nil || r.frozen.Load() {
+		return false
+	}
+	b, err := uref.Normalize(t, r.cfg)
+	if err != nil {
+		return false
+	}
+
+	r.mu.Lock()
+	_, wasLazy := r.lazy.LoadAndDelete(b)
+	v, ok := r.m.LoadAndDelete(b)
+	if !ok {
+		r.mu.Unlock()
+		if wasLazy {
+			r.notify(apis.RegistryEvent{Kind: apis.EventUnregistered, Type: b})
+		}
+		return wasLazy
+	}
+	r.count--
+	r.seq.Delete(b)
+	r.display.Delete(b)
+	r.meta.Delete(b)
+	if g := uref.GenericBaseName(b); g != "" {
+		r.generic.Delete(g)
+		var (
+			next  any
+			first uint64
+		)
+		r.m.Range(func(k, v any) bool {
+			if uref.GenericBaseName(k.(reflect.Type)) != g {
+				return true
+			}
+			if n := r.seqOf(k.(reflect.Type)); next == nil || n < first {
+				next, first = v, n
+			}
+			return true
+		})
+		if next != nil {
+			r.generic.Store(g, next)
+		}
+	}
+	r.mu.Unlock()
+
+	r.notify(apis.RegistryEvent{Kind: apis.EventUnregistered, Type: b, Name: v.(string)})
+	return true
+}
+
+// Subscribe registers fn to be called after each Register that adds an
+// entry, each successful Unregister and each Reset. Idempotent
+// re-registrations and failed calls report nothing. Events from concurrent
+// mutations may be delivered in any order. Subscriptions belong to this
+// registry instance and are not carried over when a builder rebuilds it.
+func (r *registry) Subscribe(fn func(ev apis.RegistryEvent)) (unsubscribe func()) {
+	if fn == nil {
+		return func() {}
+	}
+	r.subMu.Lock()
+	defer r.subMu.Unlock()
+	if r.subs == nil {
+		r.subs = make(map[uint64]func(apis.RegistryEvent))
+	}
+	id := r.nextSub
+	r.nextSub++
+	r.subs[id] = fn
+	return func() {
+		r.subMu.Lock()
+		defer r.subMu.Unlock()
+		delete(r.subs, id)
+	}
+}
+
+// notify delivers ev to every subscriber; it must be called without r.mu held.
+func (r *registry) notify(ev apis.RegistryEvent) {
+	r.subMu.Lock()
+	if len(r.subs) == 0 {
+		r.subMu.Unlock()
+		return
+	}
+	fns := make([]func(apis.RegistryEvent), 0, len(r.subs))
+	for _, fn := range r.subs {
+		fns = append(fns, fn)
+	}
+	r.subMu.Unlock()
+
+	for _, fn := range fns {
+		fn(ev)
+	}
+}
+
 // Lookup returns a name for a type if present.
 func (r *registry) Lookup(t reflect.Type) (name string, ok bool) {
 	if t == nil {
@@ -111,10 +274,55 @@ func (r *registry) Lookup(t reflect.Type) (name string, ok bool) {
 	if v, ok := r.m.Load(nt); ok {
 		return v.(string), true
 	}
+	if v, ok := r.lazy.Load(nt); ok {
+		if name, ok := v.(*lazyEntry).resolve(r, nt); ok {
+			return name, true
+		}
+	}
+	// Fall back to another instantiation of the same generic definition.
+	if g := uref.GenericBaseName(nt); g != "" {
+		if v, ok := r.generic.Load(g); ok {
+			return v.(string), true
+		}
+	}
+	return "", false
+}
+
+// RegisterDisplay registers t under canonical and associates display with it.
+// A later call for the same type replaces the display name.
+func (r *registry) RegisterDisplay(t reflect.Type, canonical, display string) error {
+	if display == "" {
+		return ErrEmptyName
+	}
+	if err := r.Register(t, canonical); err != nil {
+		return err
+	}
+	// Register succeeded, so normalization cannot fail here.
+	b, _ := uref.Normalize(t, r.cfg)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.display.Store(b, display)
+	return nil
+}
+
+// LookupDisplay returns the display name for a type if present.
+func (r *registry) LookupDisplay(t reflect.Type) (string, bool) {
+	if t == nil {
+		return "", false
+	}
+	nt, err := uref.Normalize(t, r.cfg)
+	if err != nil {
+		return "", false
+	}
+	if v, ok := r.display.Load(nt); ok {
+		return v.(string), true
+	}
 	return "", false
 }
 
-// Entries returns a snapshot for diagnostics/docs (order is unspecified).
+// Entries returns a snapshot for diagnostics/docs in registration order, so
+// re-registering them elsewhere keeps the same generic fallbacks.
 func (r *registry) Entries() []apis.Entry {
 	entries := make([]apis.Entry, 0, r.Count())
 	r.m.Range(func(key, value any) bool {
@@ -124,9 +332,21 @@ func (r *registry) Entries() []apis.Entry {
 		})
 		return true
 	})
+	sort.SliceStable(entries, func(i, j int) bool {
+		return r.seqOf(entries[i].Type) < r.seqOf(entries[j].Type)
+	})
 	return entries
 }
 
+// seqOf returns the registration sequence number of t. Entries that are
+// being stored concurrently sort last.
+func (r *registry) seqOf(t reflect.Type) uint64 {
+	if v, ok := r.seq.Load(t); ok {
+		return v.(uint64)
+	}
+	return math.MaxUint64
+}
+
 // Count returns the number of registered entries.
 func (r *registry) Count() int {
 	r.mu.Lock()
@@ -134,10 +354,29 @@ func (r *registry) Count() int {
 	return r.count
 }
 
-// Reset clears all registered entries.
+// Reset clears all registered entries. Subscriptions are kept.
+// After Freeze it does nothing.
 func (r *registry) Reset() {
+	if r.frozen.Load() {
+		return
+	}
 	r.mu.Lock()
-	defer r.mu.Unlock()
 	r.m = sync.Map{}
+	r.generic = sync.Map{}
+	r.seq = sync.Map{}
+	r.lazy = sync.Map{}
+	r.display = sync.Map{}
+	r.meta = sync.Map{}
 	r.count = 0
+	r.mu.Unlock()
+
+	r.notify(apis.RegistryEvent{Kind: apis.EventReset})
+}
+
+// Freeze makes the registry read-only for good: Register, RegisterDisplay,
+// RegisterDescribed and RegisterLazy return ErrFrozen, while Unregister and
+// Reset do nothing. Lazy entries registered before Freeze still resolve on
+// their first Lookup.
+func (r *registry) Freeze() {
+	r.frozen.Store(true)
 }
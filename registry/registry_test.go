@@ -17,9 +17,11 @@
 package registry_test
 
 import (
+	"errors"
 	"reflect"
 	"testing"
 
+	"dirpx.dev/rfx/apis"
 	"dirpx.dev/rfx/config"
 	"dirpx.dev/rfx/registry"
 )
@@ -161,3 +163,83 @@ func TestLookupNilAndUnknown(t *testing.T) {
 		t.Fatalf("Lookup(unknown): got (%q,%v), want ('',false)", name, ok)
 	}
 }
+
+type Gen[T any] struct{ V T }
+
+func TestRegister_GenericCoversAllInstantiations(t *testing.T) {
+	reg := registry.New(config.DefaultConfig())
+
+	if err := reg.Register(reflect.TypeOf(Gen[int]{}), "gen"); err != nil {
+		t.Fatalf("register: %v", err)
+	}
+	for _, typ := range []reflect.Type{
+		reflect.TypeOf(Gen[int]{}),
+		reflect.TypeOf(Gen[string]{}),
+		reflect.TypeOf(&Gen[[]byte]{}),
+	} {
+		if got, ok := reg.Lookup(typ); !ok || got != "gen" {
+			t.Fatalf("Lookup(%v) = (%q,%v), want (gen,true)", typ, got, ok)
+		}
+	}
+
+	// An explicit registration of another instantiation takes precedence.
+	if err := reg.Register(reflect.TypeOf(Gen[bool]{}), "gen.bool"); err != nil {
+		t.Fatalf("register: %v", err)
+	}
+	if got, _ := reg.Lookup(reflect.TypeOf(Gen[bool]{})); got != "gen.bool" {
+		t.Fatalf("explicit instantiation: got %q, want gen.bool", got)
+	}
+	if got, _ := reg.Lookup(reflect.TypeOf(Gen[float64]{})); got != "gen" {
+		t.Fatalf("first instantiation should provide the shared name, got %q", got)
+	}
+
+	// Only explicit registrations are counted.
+	if n := reg.Count(); n != 2 {
+		t.Fatalf("Count = %d, want 2", n)
+	}
+
+	reg.Reset()
+	if _, ok := reg.Lookup(reflect.TypeOf(Gen[string]{})); ok {
+		t.Fatal("generic fallback should be cleared by Reset")
+	}
+}
+
+func TestFreeze_RejectsWrites(t *testing.T) {
+	cfg := config.DefaultConfig()
+	for _, reg := range []apis.Registry{
+		registry.New(cfg),
+		registry.NewMutexBacked(cfg, 0),
+		registry.NewSharded(cfg, 4),
+	} {
+		_ = reg.Register(reflect.TypeOf(T0{}), "t0")
+		reg.(apis.Freezer).Freeze()
+
+		if err := reg.Register(reflect.TypeOf(T1{}), "t1"); !errors.Is(err, registry.ErrFrozen) {
+			t.Fatalf("%T: Register: want ErrFrozen, got %v", reg, err)
+		}
+		reg.Reset()
+		if got, ok := reg.Lookup(reflect.TypeOf(T0{})); !ok || got != "t0" {
+			t.Fatalf("%T: Lookup after Reset = (%q,%v), want (t0,true)", reg, got, ok)
+		}
+	}
+}
+
+func TestFreeze_PendingLazyStillResolves(t *testing.T) {
+	reg := registry.New(config.DefaultConfig())
+	lazy := reg.(apis.LazyRegistry)
+	_ = lazy.RegisterLazy(reflect.TypeOf(T0{}), func() (string, error) { return "t0", nil })
+	reg.(apis.Freezer).Freeze()
+
+	if err := lazy.RegisterLazy(reflect.TypeOf(T1{}), func() (string, error) { return "t1", nil }); !errors.Is(err, registry.ErrFrozen) {
+		t.Fatalf("RegisterLazy: want ErrFrozen, got %v", err)
+	}
+	if err := reg.(apis.DisplayRegistry).RegisterDisplay(reflect.TypeOf(T1{}), "t1", "T1"); !errors.Is(err, registry.ErrFrozen) {
+		t.Fatalf("RegisterDisplay: want ErrFrozen, got %v", err)
+	}
+	if reg.(apis.Unregisterer).Unregister(reflect.TypeOf(T0{})) {
+		t.Fatal("Unregister reported true after Freeze")
+	}
+	if got, ok := reg.Lookup(reflect.TypeOf(T0{})); !ok || got != "t0" {
+		t.Fatalf("Lookup = (%q,%v), want (t0,true)", got, ok)
+	}
+}
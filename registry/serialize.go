@@ -0,0 +1,65 @@
+/*
+   Copyright 2025 The DIRPX Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package registry
+
+import (
+	"reflect"
+
+	"dirpx.dev/rfx/apis"
+)
+
+// SerializableEntry is a gob/json-friendly form of apis.Entry that identifies
+// the type by package path and type name instead of a reflect.Type.
+type SerializableEntry struct {
+	// Name is the registered name.
+	Name string
+	// PkgPath is the type's package path ("" for builtin types).
+	PkgPath string
+	// TypeName is the type's name within its package.
+	TypeName string
+}
+
+// ToSerializable converts entries into their serializable form.
+func ToSerializable(entries []apis.Entry) []SerializableEntry {
+	out := make([]SerializableEntry, 0, len(entries))
+	for _, e := range entries {
+		if e.Type == nil {
+			continue
+		}
+		out = append(out, SerializableEntry{
+			Name:     e.Name,
+			PkgPath:  e.Type.PkgPath(),
+			TypeName: e.Type.Name(),
+		})
+	}
+	return out
+}
+
+// FromSerializable reconstructs entries using resolve to map a package path
+// and type name back to a reflect.Type known to the binary. Entries that
+// resolve cannot map are skipped.
+func FromSerializable(entries []SerializableEntry, resolve func(pkgPath, typeName string) (reflect.Type, bool)) []apis.Entry {
+	out := make([]apis.Entry, 0, len(entries))
+	for _, se := range entries {
+		t, ok := resolve(se.PkgPath, se.TypeName)
+		if !ok || t == nil {
+			continue
+		}
+		out = append(out, apis.Entry{Type: t, Name: se.Name})
+	}
+	return out
+}
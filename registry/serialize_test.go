@@ -0,0 +1,65 @@
+/*
+   Copyright 2025 The DIRPX Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package registry_test
+
+import (
+	"bytes"
+	"encoding/gob"
+	"reflect"
+	"testing"
+
+	"dirpx.dev/rfx/apis"
+	"dirpx.dev/rfx/registry"
+)
+
+func TestSerializable_GobRoundTrip(t *testing.T) {
+	known := map[string]reflect.Type{}
+	for _, typ := range []reflect.Type{reflect.TypeOf(T0{}), reflect.TypeOf(T1{}), reflect.TypeOf(0)} {
+		known[typ.PkgPath()+"."+typ.Name()] = typ
+	}
+	resolve := func(pkgPath, typeName string) (reflect.Type, bool) {
+		typ, ok := known[pkgPath+"."+typeName]
+		return typ, ok
+	}
+
+	in := []apis.Entry{
+		{Type: reflect.TypeOf(T0{}), Name: "t0"},
+		{Type: reflect.TypeOf(T1{}), Name: "t1"},
+		{Type: reflect.TypeOf(0), Name: "int"},
+	}
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(registry.ToSerializable(in)); err != nil {
+		t.Fatalf("encode: %v", err)
+	}
+	var decoded []registry.SerializableEntry
+	if err := gob.NewDecoder(&buf).Decode(&decoded); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+
+	if out := registry.FromSerializable(decoded, resolve); !reflect.DeepEqual(out, in) {
+		t.Fatalf("round trip = %v, want %v", out, in)
+	}
+}
+
+func TestFromSerializable_SkipsUnknownTypes(t *testing.T) {
+	entries := []registry.SerializableEntry{{Name: "gone", PkgPath: "example.com/x", TypeName: "Gone"}}
+	none := func(string, string) (reflect.Type, bool) { return nil, false }
+	if out := registry.FromSerializable(entries, none); len(out) != 0 {
+		t.Fatalf("FromSerializable = %v, want empty", out)
+	}
+}
@@ -0,0 +1,247 @@
+/*
+   Copyright 2025 The DIRPX Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package registry
+
+import (
+	"reflect"
+	"sort"
+	"sync"
+	"sync/atomic"
+
+	"dirpx.dev/rfx/apis"
+	"dirpx.dev/rfx/config"
+	uref "dirpx.dev/rfx/utils/reflect"
+)
+
+// DefaultShards is the shard count used by NewSharded when shards <= 0.
+const DefaultShards = 32
+
+// NewSharded constructs a Registry that spreads entries over shards
+// independently locked partitions, keyed by the normalized reflect.Type.
+// It trades a slower Entries (which locks every shard) for less contention
+// under bursty concurrent registration of very large type sets.
+//
+// Normalization and the generic-instantiation fallback follow New: the
+// first registered instantiation of a generic definition, in Entries order,
+// provides the shared name. Registrations of generic instantiations are
+// serialized across shards to keep that order; others are not.
+func NewSharded(cfg apis.Config, shards int) apis.Registry {
+	if cfg.MaxUnwrap < 0 {
+		cfg.MaxUnwrap = config.DefaultMaxUnwrap
+	}
+	if shards <= 0 {
+		shards = DefaultShards
+	}
+	r := &shardedRegistry{cfg: cfg, shards: make([]shard, shards)}
+	for i := range r.shards {
+		r.shards[i].m = make(map[reflect.Type]string)
+		r.shards[i].seq = make(map[reflect.Type]uint64)
+	}
+	return r
+}
+
+// Ensure shardedRegistry implements apis.Registry and apis.Freezer.
+var (
+	_ apis.Registry = (*shardedRegistry)(nil)
+	_ apis.Freezer  = (*shardedRegistry)(nil)
+)
+
+// shardedRegistry is a Registry partitioned into independently locked shards.
+type shardedRegistry struct {
+	// cfg is the configuration used for type normalization.
+	cfg apis.Config
+	// shards holds the partitions; a type always maps to the same shard.
+	shards []shard
+	// count is the total number of entries, updated under the owning shard's
+	// lock so Count needs no shard locks.
+	count atomic.Int64
+	// nextSeq is the sequence number of the next registration.
+	nextSeq atomic.Uint64
+	// generic maps a generic definition to the first instantiation's name.
+	// It is only accessed under some shard's lock, so Reset can swap it.
+	generic sync.Map // map[string]string
+	// genericMu orders generic instantiations across shards: it covers both
+	// the sequence number and the generic entry, so the instantiation first
+	// in Entries is the one that provides the shared name.
+	genericMu sync.Mutex
+	// frozen is set by Freeze and makes every write fail.
+	frozen atomic.Bool
+}
+
+// shard is one partition of a shardedRegistry.
+type shard struct {
+	mu  sync.RWMutex
+	m   map[reflect.Type]string
+	seq map[reflect.Type]uint64
+}
+
+// shardFor returns the shard owning the normalized type t.
+func (r *shardedRegistry) shardFor(t reflect.Type) *shard {
+	// reflect.Type values are unique *rtype pointers; mix the address so
+	// that aligned pointers still spread across shards.
+	h := uint64(reflect.ValueOf(t).Pointer())
+	h ^= h >> 33
+	h *= 0xff51afd7ed558ccd
+	h ^= h >> 33
+	return &r.shards[h%uint64(len(r.shards))]
+}
+
+// Register associates the nearest named type of t with the given name.
+// It is idempotent for the same (type,name) pair.
+// After Freeze it returns ErrFrozen.
+func (r *shardedRegistry) Register(t reflect.Type, name string) error {
+	if r.frozen.Load() {
+		return ErrFrozen
+	}
+	if t == nil {
+		return ErrNilType
+	}
+	if name == "" {
+		return ErrEmptyName
+	}
+	b, err := uref.Normalize(t, r.cfg)
+	if err != nil {
+		return err
+	}
+
+	s := r.shardFor(b)
+
+	// Fast read path: idempotency / conflict check under the read lock.
+	s.mu.RLock()
+	old, ok := s.m[b]
+	s.mu.RUnlock()
+	if ok {
+		if old == name {
+			return nil
+		}
+		return ErrConflictingRegistration
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// Re-check under lock in case another goroutine stored meanwhile.
+	if old, ok := s.m[b]; ok {
+		if old == name {
+			return nil
+		}
+		return ErrConflictingRegistration
+	}
+	s.m[b] = name
+	r.count.Add(1)
+	if g := uref.GenericBaseName(b); g != "" {
+		r.genericMu.Lock()
+		s.seq[b] = r.nextSeq.Add(1)
+		r.generic.LoadOrStore(g, name)
+		r.genericMu.Unlock()
+		return nil
+	}
+	s.seq[b] = r.nextSeq.Add(1)
+	return nil
+}
+
+// Lookup returns a name for a type if present.
+func (r *shardedRegistry) Lookup(t reflect.Type) (string, bool) {
+	if t == nil {
+		return "", false
+	}
+	b, err := uref.Normalize(t, r.cfg)
+	if err != nil {
+		return "", false
+	}
+	s := r.shardFor(b)
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if name, ok := s.m[b]; ok {
+		return name, true
+	}
+	if g := uref.GenericBaseName(b); g != "" {
+		if v, ok := r.generic.Load(g); ok {
+			return v.(string), true
+		}
+	}
+	return "", false
+}
+
+// Entries returns a snapshot for diagnostics/docs in registration order.
+// All shards are read-locked together, so the snapshot is consistent.
+func (r *shardedRegistry) Entries() []apis.Entry {
+	r.rlockAll()
+	defer r.runlockAll()
+
+	n := 0
+	for i := range r.shards {
+		n += len(r.shards[i].m)
+	}
+	entries := make([]apis.Entry, 0, n)
+	seq := make(map[reflect.Type]uint64, n)
+	for i := range r.shards {
+		for t, name := range r.shards[i].m {
+			entries = append(entries, apis.Entry{Type: t, Name: name})
+			seq[t] = r.shards[i].seq[t]
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return seq[entries[i].Type] < seq[entries[j].Type]
+	})
+	return entries
+}
+
+// Count returns the number of registered entries across all shards.
+func (r *shardedRegistry) Count() int {
+	return int(r.count.Load())
+}
+
+// Reset clears all registered entries. After Freeze it does nothing.
+func (r *shardedRegistry) Reset() {
+	if r.frozen.Load() {
+		return
+	}
+	for i := range r.shards {
+		s := &r.shards[i]
+		s.mu.Lock()
+	}
+	for i := range r.shards {
+		s := &r.shards[i]
+		s.m = make(map[reflect.Type]string)
+		s.seq = make(map[reflect.Type]uint64)
+	}
+	r.generic = sync.Map{}
+	r.count.Store(0)
+	for i := range r.shards {
+		r.shards[i].mu.Unlock()
+	}
+}
+
+// rlockAll read-locks every shard in index order.
+func (r *shardedRegistry) rlockAll() {
+	for i := range r.shards {
+		r.shards[i].mu.RLock()
+	}
+}
+
+// runlockAll releases the locks taken by rlockAll.
+func (r *shardedRegistry) runlockAll() {
+	for i := range r.shards {
+		r.shards[i].mu.RUnlock()
+	}
+}
+
+// Freeze makes the registry read-only for good: Register returns ErrFrozen
+// and Reset does nothing.
+func (r *shardedRegistry) Freeze() {
+	r.frozen.Store(true)
+}
@@ -0,0 +1,176 @@
+/*
+   Copyright 2025 The DIRPX Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package registry_test
+
+import (
+	"errors"
+	"reflect"
+	"runtime"
+	"sync"
+	"testing"
+
+	"dirpx.dev/rfx/apis"
+	"dirpx.dev/rfx/config"
+	"dirpx.dev/rfx/registry"
+)
+
+var shardTestTypes = []reflect.Type{
+	reflect.TypeOf(T0{}), reflect.TypeOf(T1{}), reflect.TypeOf(T2{}),
+	reflect.TypeOf(T3{}), reflect.TypeOf(T4{}), reflect.TypeOf(T5{}),
+	reflect.TypeOf(T6{}), reflect.TypeOf(T7{}), reflect.TypeOf(T8{}),
+	reflect.TypeOf(T9{}),
+}
+
+func TestSharded_Contract(t *testing.T) {
+	reg := registry.NewSharded(config.DefaultConfig(), 4)
+
+	for i, tt := range shardTestTypes {
+		if err := reg.Register(tt, "t"+string(rune('0'+i))); err != nil {
+			t.Fatalf("register %v: %v", tt, err)
+		}
+	}
+	// Idempotent, normalized and conflicting registrations.
+	if err := reg.Register(reflect.TypeOf(&T0{}), "t0"); err != nil {
+		t.Fatalf("idempotent register via pointer: %v", err)
+	}
+	if err := reg.Register(reflect.TypeOf(T0{}), "other"); !errors.Is(err, registry.ErrConflictingRegistration) {
+		t.Fatalf("want ErrConflictingRegistration, got %v", err)
+	}
+	if err := reg.Register(nil, "x"); !errors.Is(err, registry.ErrNilType) {
+		t.Fatalf("want ErrNilType, got %v", err)
+	}
+	if err := reg.Register(reflect.TypeOf(T0{}), ""); !errors.Is(err, registry.ErrEmptyName) {
+		t.Fatalf("want ErrEmptyName, got %v", err)
+	}
+
+	if got, ok := reg.Lookup(reflect.TypeOf([]*T3{})); !ok || got != "t3" {
+		t.Fatalf("Lookup([]*T3) = (%q,%v), want (t3,true)", got, ok)
+	}
+	if n := reg.Count(); n != len(shardTestTypes) {
+		t.Fatalf("Count = %d, want %d", n, len(shardTestTypes))
+	}
+	if n := len(reg.Entries()); n != len(shardTestTypes) {
+		t.Fatalf("len(Entries) = %d, want %d", n, len(shardTestTypes))
+	}
+
+	reg.Reset()
+	if n := reg.Count(); n != 0 {
+		t.Fatalf("Count after Reset = %d, want 0", n)
+	}
+	if _, ok := reg.Lookup(reflect.TypeOf(T0{})); ok {
+		t.Fatal("Lookup after Reset should miss")
+	}
+}
+
+func TestSharded_GenericFallback(t *testing.T) {
+	reg := registry.NewSharded(config.DefaultConfig(), 4)
+	_ = reg.Register(reflect.TypeOf(Gen[int]{}), "gen.int")
+	_ = reg.Register(reflect.TypeOf(Gen[bool]{}), "gen.bool")
+
+	if got, ok := reg.Lookup(reflect.TypeOf(Gen[string]{})); !ok || got != "gen.int" {
+		t.Fatalf("Lookup(Gen[string]) = (%q,%v), want (gen.int,true)", got, ok)
+	}
+	if got, _ := reg.Lookup(reflect.TypeOf(Gen[bool]{})); got != "gen.bool" {
+		t.Fatalf("explicit instantiation: got %q, want gen.bool", got)
+	}
+	if e := reg.Entries(); e[0].Name != "gen.int" || e[1].Name != "gen.bool" {
+		t.Fatalf("Entries not in registration order: %+v", e)
+	}
+
+	reg.Reset()
+	if _, ok := reg.Lookup(reflect.TypeOf(Gen[string]{})); ok {
+		t.Fatal("Lookup after Reset should miss")
+	}
+}
+
+func TestSharded_ConcurrentCountMatchesEntries(t *testing.T) {
+	reg := registry.NewSharded(config.DefaultConfig(), 0)
+
+	var wg sync.WaitGroup
+	workers := runtime.GOMAXPROCS(0) * 2
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				tt := shardTestTypes[i%len(shardTestTypes)]
+				_ = reg.Register(tt, tt.Name())
+				_, _ = reg.Lookup(tt)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if n := reg.Count(); n != len(shardTestTypes) || len(reg.Entries()) != n {
+		t.Fatalf("Count = %d, len(Entries) = %d, want %d", n, len(reg.Entries()), len(shardTestTypes))
+	}
+}
+
+func TestSharded_ConcurrentGenericFirstInEntries(t *testing.T) {
+	gens := []reflect.Type{
+		reflect.TypeOf(Gen[int]{}), reflect.TypeOf(Gen[bool]{}),
+		reflect.TypeOf(Gen[string]{}), reflect.TypeOf(Gen[float64]{}),
+		reflect.TypeOf(Gen[int8]{}), reflect.TypeOf(Gen[uint]{}),
+	}
+	for round := 0; round < 100; round++ {
+		reg := registry.NewSharded(config.DefaultConfig(), 8)
+		var wg sync.WaitGroup
+		wg.Add(len(gens))
+		for _, g := range gens {
+			go func() {
+				defer wg.Done()
+				_ = reg.Register(g, g.String())
+			}()
+		}
+		wg.Wait()
+
+		first := reg.Entries()[0].Name
+		if got, _ := reg.Lookup(reflect.TypeOf(Gen[complex64]{})); got != first {
+			t.Fatalf("round %d: fallback = %q, want the first entry %q", round, got, first)
+		}
+	}
+}
+
+// benchmarkRegistry runs a parallel mix of idempotent registrations, lookups
+// and counts, the pattern that contends on a single write lock.
+func benchmarkRegistry(b *testing.B, reg apis.Registry) {
+	for _, tt := range shardTestTypes {
+		_ = reg.Register(tt, tt.Name())
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			tt := shardTestTypes[i%len(shardTestTypes)]
+			_ = reg.Register(tt, tt.Name())
+			_, _ = reg.Lookup(tt)
+			if i%16 == 0 {
+				_ = reg.Count()
+			}
+			i++
+		}
+	})
+}
+
+func BenchmarkRegistry_SingleLock(b *testing.B) {
+	benchmarkRegistry(b, registry.New(config.DefaultConfig()))
+}
+
+func BenchmarkRegistry_Sharded(b *testing.B) {
+	benchmarkRegistry(b, registry.NewSharded(config.DefaultConfig(), registry.DefaultShards))
+}
@@ -0,0 +1,120 @@
+/*
+   Copyright 2025 The DIRPX Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package registry
+
+import (
+	"reflect"
+	"sort"
+
+	"dirpx.dev/rfx/apis"
+	"dirpx.dev/rfx/config"
+	uref "dirpx.dev/rfx/utils/reflect"
+)
+
+// SnapshotOf returns an immutable snapshot of reg. It uses reg's own Snapshot
+// when reg implements apis.Snapshotter, and otherwise builds one from
+// Entries(), normalizing lookups with cfg.
+func SnapshotOf(reg apis.Registry, cfg apis.Config) apis.RegistrySnapshot {
+	if s, ok := reg.(apis.Snapshotter); ok {
+		return s.Snapshot()
+	}
+	if cfg.MaxUnwrap < 0 {
+		cfg.MaxUnwrap = config.DefaultMaxUnwrap
+	}
+	return newSnapshot(cfg, reg.Entries(), nil)
+}
+
+// Snapshot builds an immutable view of the current entries, including the
+// generic-instantiation fallback. Later registrations are not reflected.
+func (r *registry) Snapshot() apis.RegistrySnapshot {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	generic := make(map[string]string)
+	r.generic.Range(func(k, v any) bool {
+		generic[k.(string)] = v.(string)
+		return true
+	})
+	entries := make([]apis.Entry, 0, r.count)
+	r.m.Range(func(k, v any) bool {
+		entries = append(entries, apis.Entry{Type: k.(reflect.Type), Name: v.(string)})
+		return true
+	})
+	return newSnapshot(r.cfg, entries, generic)
+}
+
+// Snapshot builds an immutable view of the current entries.
+// Later registrations are not reflected.
+func (r *shardedRegistry) Snapshot() apis.RegistrySnapshot {
+	return newSnapshot(r.cfg, r.Entries(), nil)
+}
+
+// Ensure both registries implement apis.Snapshotter.
+var (
+	_ apis.Snapshotter = (*registry)(nil)
+	_ apis.Snapshotter = (*shardedRegistry)(nil)
+)
+
+// snapshot is an immutable apis.RegistrySnapshot over plain maps.
+type snapshot struct {
+	cfg     apis.Config
+	m       map[reflect.Type]string
+	generic map[string]string
+	names   []string
+}
+
+// newSnapshot builds a snapshot from entries. generic may be nil.
+func newSnapshot(cfg apis.Config, entries []apis.Entry, generic map[string]string) *snapshot {
+	s := &snapshot{
+		cfg:     cfg,
+		m:       make(map[reflect.Type]string, len(entries)),
+		generic: generic,
+		names:   make([]string, 0, len(entries)),
+	}
+	for _, e := range entries {
+		s.m[e.Type] = e.Name
+		s.names = append(s.names, e.Name)
+	}
+	sort.Strings(s.names)
+	return s
+}
+
+// Lookup returns a name for the normalized t if present.
+func (s *snapshot) Lookup(t reflect.Type) (string, bool) {
+	if t == nil {
+		return "", false
+	}
+	nt, err := uref.Normalize(t, s.cfg)
+	if err != nil {
+		return "", false
+	}
+	if name, ok := s.m[nt]; ok {
+		return name, true
+	}
+	if g := uref.GenericBaseName(nt); g != "" {
+		if name, ok := s.generic[g]; ok {
+			return name, true
+		}
+	}
+	return "", false
+}
+
+// Len returns the number of entries.
+func (s *snapshot) Len() int { return len(s.m) }
+
+// Names returns the sorted names; the slice must not be modified.
+func (s *snapshot) Names() []string { return s.names }
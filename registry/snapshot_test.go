@@ -0,0 +1,74 @@
+/*
+   Copyright 2025 The DIRPX Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package registry_test
+
+import (
+	"reflect"
+	"testing"
+
+	"dirpx.dev/rfx/apis"
+	"dirpx.dev/rfx/config"
+	"dirpx.dev/rfx/registry"
+)
+
+func TestSnapshot_ImmutableView(t *testing.T) {
+	for name, reg := range map[string]apis.Registry{
+		"default": registry.New(config.DefaultConfig()),
+		"sharded": registry.NewSharded(config.DefaultConfig(), 2),
+	} {
+		t.Run(name, func(t *testing.T) {
+			_ = reg.Register(reflect.TypeOf(T1{}), "t1")
+			_ = reg.Register(reflect.TypeOf(T0{}), "t0")
+
+			snap := registry.SnapshotOf(reg, config.DefaultConfig())
+			_ = reg.Register(reflect.TypeOf(T2{}), "t2")
+
+			if snap.Len() != 2 {
+				t.Fatalf("Len = %d, want 2", snap.Len())
+			}
+			if got := snap.Names(); !reflect.DeepEqual(got, []string{"t0", "t1"}) {
+				t.Fatalf("Names = %v, want [t0 t1]", got)
+			}
+			if got, ok := snap.Lookup(reflect.TypeOf(&T0{})); !ok || got != "t0" {
+				t.Fatalf("Lookup(*T0) = (%q,%v), want (t0,true)", got, ok)
+			}
+			if _, ok := snap.Lookup(reflect.TypeOf(T2{})); ok {
+				t.Fatal("snapshot must not reflect later registrations")
+			}
+		})
+	}
+}
+
+func TestSnapshot_GenericFallback(t *testing.T) {
+	reg := registry.New(config.DefaultConfig())
+	_ = reg.Register(reflect.TypeOf(Gen[int]{}), "gen")
+
+	snap := reg.(apis.Snapshotter).Snapshot()
+	if got, ok := snap.Lookup(reflect.TypeOf(Gen[string]{})); !ok || got != "gen" {
+		t.Fatalf("Lookup(Gen[string]) = (%q,%v), want (gen,true)", got, ok)
+	}
+}
+
+func TestSnapshotOf_GenericRegistry(t *testing.T) {
+	reg := registry.Namespaced(registry.New(config.DefaultConfig()), "app")
+	_ = reg.Register(reflect.TypeOf(T0{}), "t0")
+
+	snap := registry.SnapshotOf(reg, config.DefaultConfig())
+	if got, ok := snap.Lookup(reflect.TypeOf(T0{})); !ok || got != "app.t0" {
+		t.Fatalf("Lookup(T0) = (%q,%v), want (app.t0,true)", got, ok)
+	}
+}
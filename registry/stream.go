@@ -0,0 +1,87 @@
+/*
+   Copyright 2025 The DIRPX Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package registry
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+
+	"dirpx.dev/rfx/apis"
+	uref "dirpx.dev/rfx/utils/reflect"
+)
+
+// Entry stream formats supported by WriteEntries.
+const (
+	// FormatJSONL writes one {"type":...,"name":...} object per line.
+	FormatJSONL = "jsonl"
+	// FormatCSV writes a "type,name" header followed by one row per entry.
+	FormatCSV = "csv"
+)
+
+// ErrUnknownFormat is returned by WriteEntries for an unsupported format.
+var ErrUnknownFormat = errors.New("rfx(registry): unknown entry format")
+
+// Ensure registry implements apis.EntryWriter.
+var _ apis.EntryWriter = (*registry)(nil)
+
+// streamEntry is the JSON form of an entry in a FormatJSONL stream.
+type streamEntry struct {
+	// Type is the registered type rendered as "pkgpath.Type".
+	Type string `json:"type"`
+	// Name is the associated name.
+	Name string `json:"name"`
+}
+
+// WriteEntries streams the entries to w in FormatJSONL or FormatCSV without
+// building the full entry slice, so memory stays flat for huge registries.
+// Types are rendered as "pkgpath.Type"; order is unspecified. Entries
+// registered concurrently may or may not be included.
+func (r *registry) WriteEntries(w io.Writer, format string) error {
+	var (
+		write func(typ, name string) error
+		flush func() error
+	)
+	switch format {
+	case FormatJSONL:
+		enc := json.NewEncoder(w)
+		write = func(typ, name string) error { return enc.Encode(streamEntry{Type: typ, Name: name}) }
+		flush = func() error { return nil }
+	case FormatCSV:
+		cw := csv.NewWriter(w)
+		if err := cw.Write([]string{"type", "name"}); err != nil {
+			return err
+		}
+		write = func(typ, name string) error { return cw.Write([]string{typ, name}) }
+		flush = func() error { cw.Flush(); return cw.Error() }
+	default:
+		return fmt.Errorf("%w: %q", ErrUnknownFormat, format)
+	}
+
+	var err error
+	r.m.Range(func(key, value any) bool {
+		err = write(uref.FullName(key.(reflect.Type)), value.(string))
+		return err == nil
+	})
+	if err != nil {
+		return err
+	}
+	return flush()
+}
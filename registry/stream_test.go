@@ -0,0 +1,79 @@
+/*
+   Copyright 2025 The DIRPX Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package registry_test
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"dirpx.dev/rfx/apis"
+	"dirpx.dev/rfx/config"
+	"dirpx.dev/rfx/registry"
+)
+
+func streamRegistry(t *testing.T) apis.EntryWriter {
+	t.Helper()
+	reg := registry.New(config.DefaultConfig())
+	_ = reg.Register(reflect.TypeOf(T0{}), "t0")
+	_ = reg.Register(reflect.TypeOf(T1{}), "t,1")
+	return reg.(apis.EntryWriter)
+}
+
+// sortedLines splits s into lines, keeping the first n in place and sorting the rest.
+func sortedLines(s string, n int) []string {
+	lines := strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+	sort.Strings(lines[n:])
+	return lines
+}
+
+func TestWriteEntries_JSONL(t *testing.T) {
+	var buf bytes.Buffer
+	if err := streamRegistry(t).WriteEntries(&buf, registry.FormatJSONL); err != nil {
+		t.Fatalf("WriteEntries: %v", err)
+	}
+	pkg := reflect.TypeOf(T0{}).PkgPath()
+	want := []string{
+		`{"type":"` + pkg + `.T0","name":"t0"}`,
+		`{"type":"` + pkg + `.T1","name":"t,1"}`,
+	}
+	if got := sortedLines(buf.String(), 0); !reflect.DeepEqual(got, want) {
+		t.Fatalf("jsonl = %q, want %q", got, want)
+	}
+}
+
+func TestWriteEntries_CSV(t *testing.T) {
+	var buf bytes.Buffer
+	if err := streamRegistry(t).WriteEntries(&buf, registry.FormatCSV); err != nil {
+		t.Fatalf("WriteEntries: %v", err)
+	}
+	pkg := reflect.TypeOf(T0{}).PkgPath()
+	want := []string{"type,name", pkg + ".T0,t0", pkg + `.T1,"t,1"`}
+	if got := sortedLines(buf.String(), 1); !reflect.DeepEqual(got, want) {
+		t.Fatalf("csv = %q, want %q", got, want)
+	}
+}
+
+func TestWriteEntries_UnknownFormat(t *testing.T) {
+	err := streamRegistry(t).WriteEntries(&bytes.Buffer{}, "xml")
+	if !errors.Is(err, registry.ErrUnknownFormat) {
+		t.Fatalf("WriteEntries(xml): want ErrUnknownFormat, got %v", err)
+	}
+}
@@ -0,0 +1,123 @@
+/*
+   Copyright 2025 The DIRPX Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package registry_test
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+
+	"dirpx.dev/rfx/apis"
+	"dirpx.dev/rfx/config"
+	"dirpx.dev/rfx/registry"
+)
+
+// eventLog collects registry events.
+type eventLog struct {
+	mu  sync.Mutex
+	evs []apis.RegistryEvent
+}
+
+func (l *eventLog) add(ev apis.RegistryEvent) {
+	l.mu.Lock()
+	l.evs = append(l.evs, ev)
+	l.mu.Unlock()
+}
+
+func (l *eventLog) events() []apis.RegistryEvent {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]apis.RegistryEvent(nil), l.evs...)
+}
+
+func TestRegistry_SubscribeEvents(t *testing.T) {
+	reg := registry.New(config.DefaultConfig())
+	var a, b eventLog
+	reg.(apis.Subscriber).Subscribe(a.add)
+	unsubB := reg.(apis.Subscriber).Subscribe(b.add)
+
+	t0, t1 := reflect.TypeOf(T0{}), reflect.TypeOf(T1{})
+	_ = reg.Register(t0, "t0")
+	_ = reg.Register(reflect.PtrTo(t0), "t0") // idempotent: no event
+	_ = reg.Register(t0, "other")             // conflict: no event
+	_ = reg.Register(t1, "t1")
+	unsubB()
+	if !reg.(apis.Unregisterer).Unregister(t1) {
+		t.Fatal("Unregister(t1) = false, want true")
+	}
+	if reg.(apis.Unregisterer).Unregister(t1) {
+		t.Fatal("second Unregister(t1) = true, want false")
+	}
+	reg.Reset()
+
+	want := []apis.RegistryEvent{
+		{Kind: apis.EventRegistered, Type: t0, Name: "t0"},
+		{Kind: apis.EventRegistered, Type: t1, Name: "t1"},
+		{Kind: apis.EventUnregistered, Type: t1, Name: "t1"},
+		{Kind: apis.EventReset},
+	}
+	if got := a.events(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("events = %+v, want %+v", got, want)
+	}
+	if got := b.events(); !reflect.DeepEqual(got, want[:2]) {
+		t.Fatalf("unsubscribed events = %+v, want %+v", got, want[:2])
+	}
+}
+
+func TestRegistry_UnregisterGenericFallback(t *testing.T) {
+	reg := registry.New(config.DefaultConfig())
+	_ = reg.Register(reflect.TypeOf(Gen[int]{}), "gen.int")
+	_ = reg.Register(reflect.TypeOf(Gen[bool]{}), "gen.bool")
+
+	reg.(apis.Unregisterer).Unregister(reflect.TypeOf(Gen[int]{}))
+
+	// The remaining instantiation now provides the shared name.
+	if got, ok := reg.Lookup(reflect.TypeOf(Gen[string]{})); !ok || got != "gen.bool" {
+		t.Fatalf("Lookup(Gen[string]) = (%q,%v), want (gen.bool,true)", got, ok)
+	}
+	if n := reg.Count(); n != 1 {
+		t.Fatalf("Count() = %d, want 1", n)
+	}
+}
+
+func TestRegistry_UnregisterGenericFallbackKeepsOrder(t *testing.T) {
+	reg := registry.New(config.DefaultConfig())
+	_ = reg.Register(reflect.TypeOf(Gen[int]{}), "gen.int")
+	_ = reg.Register(reflect.TypeOf(Gen[bool]{}), "gen.bool")
+	_ = reg.Register(reflect.TypeOf(Gen[uint]{}), "gen.uint")
+	_ = reg.Register(reflect.TypeOf(Gen[int8]{}), "gen.int8")
+	_ = reg.Register(reflect.TypeOf(Gen[int16]{}), "gen.int16")
+
+	// The earliest remaining registration takes over, regardless of map order.
+	reg.(apis.Unregisterer).Unregister(reflect.TypeOf(Gen[int]{}))
+	if got, _ := reg.Lookup(reflect.TypeOf(Gen[string]{})); got != "gen.bool" {
+		t.Fatalf("Lookup(Gen[string]) = %q, want gen.bool", got)
+	}
+	reg.(apis.Unregisterer).Unregister(reflect.TypeOf(Gen[bool]{}))
+	if got, _ := reg.Lookup(reflect.TypeOf(Gen[string]{})); got != "gen.uint" {
+		t.Fatalf("Lookup(Gen[string]) = %q, want gen.uint", got)
+	}
+
+	// Entries are reported in registration order.
+	var names []string
+	for _, e := range reg.Entries() {
+		names = append(names, e.Name)
+	}
+	if want := []string{"gen.uint", "gen.int8", "gen.int16"}; !reflect.DeepEqual(names, want) {
+		t.Fatalf("Entries = %v, want %v", names, want)
+	}
+}
@@ -0,0 +1,175 @@
+/*
+   Copyright 2025 The DIRPX Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package registry
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+
+	"dirpx.dev/rfx/apis"
+)
+
+// Wrapper is an embeddable base for apis.Registry decorators. It forwards the
+// core methods and every optional registry interface to the wrapped Registry:
+// reads the wrapped registry does not support fall back to its Entries, and
+// writes fail with an error wrapping errors.ErrUnsupported. Decorators embed
+// Wrapper and override only the methods whose behavior they change.
+type Wrapper struct {
+	apis.Registry
+	// Config normalizes lookups in snapshots built from Entries and in copies
+	// made to stream entries.
+	Config apis.Config
+}
+
+// Ensure Wrapper implements the optional registry interfaces.
+var (
+	_ apis.DisplayRegistry  = Wrapper{}
+	_ apis.MetadataRegistry = Wrapper{}
+	_ apis.Snapshotter      = Wrapper{}
+	_ apis.PrefixQuerier    = Wrapper{}
+	_ apis.EntryWriter      = Wrapper{}
+	_ apis.Subscriber       = Wrapper{}
+	_ apis.LazyRegistry     = Wrapper{}
+	_ apis.Unregisterer     = Wrapper{}
+	_ apis.Freezer          = Wrapper{}
+)
+
+// RegisterDisplay delegates to the wrapped registry if it supports display
+// names.
+func (w Wrapper) RegisterDisplay(t reflect.Type, canonical, display string) error {
+	if d, ok := w.Registry.(apis.DisplayRegistry); ok {
+		return d.RegisterDisplay(t, canonical, display)
+	}
+	return w.Unsupported()
+}
+
+// LookupDisplay delegates to the wrapped registry if it supports display
+// names.
+func (w Wrapper) LookupDisplay(t reflect.Type) (string, bool) {
+	if d, ok := w.Registry.(apis.DisplayRegistry); ok {
+		return d.LookupDisplay(t)
+	}
+	return "", false
+}
+
+// RegisterDescribed delegates to the wrapped registry if it supports
+// descriptions.
+func (w Wrapper) RegisterDescribed(t reflect.Type, d apis.Description) error {
+	if m, ok := w.Registry.(apis.MetadataRegistry); ok {
+		return m.RegisterDescribed(t, d)
+	}
+	return w.Unsupported()
+}
+
+// LookupDescription delegates to the wrapped registry if it supports
+// descriptions.
+func (w Wrapper) LookupDescription(t reflect.Type) (apis.Description, bool) {
+	if m, ok := w.Registry.(apis.MetadataRegistry); ok {
+		return m.LookupDescription(t)
+	}
+	return apis.Description{}, false
+}
+
+// Snapshot delegates to the wrapped registry, or builds one from its Entries.
+func (w Wrapper) Snapshot() apis.RegistrySnapshot {
+	return SnapshotOf(w.Registry, w.Config)
+}
+
+// CountByPrefix delegates to the wrapped registry, or filters its Entries.
+func (w Wrapper) CountByPrefix(prefix string) int {
+	if q, ok := w.Registry.(apis.PrefixQuerier); ok {
+		return q.CountByPrefix(prefix)
+	}
+	return len(w.EntriesByPrefix(prefix))
+}
+
+// EntriesByPrefix delegates to the wrapped registry, or filters its Entries.
+func (w Wrapper) EntriesByPrefix(prefix string) []apis.Entry {
+	if q, ok := w.Registry.(apis.PrefixQuerier); ok {
+		return q.EntriesByPrefix(prefix)
+	}
+	var entries []apis.Entry
+	for _, e := range w.Registry.Entries() {
+		if strings.HasPrefix(e.Name, prefix) {
+			entries = append(entries, e)
+		}
+	}
+	return entries
+}
+
+// WriteEntries delegates to the wrapped registry, or streams a copy of its
+// Entries.
+func (w Wrapper) WriteEntries(out io.Writer, format string) error {
+	if ew, ok := w.Registry.(apis.EntryWriter); ok {
+		return ew.WriteEntries(out, format)
+	}
+	cp := New(w.Config).(*registry)
+	for _, e := range w.Registry.Entries() {
+		_ = cp.Register(e.Type, e.Name)
+	}
+	return cp.WriteEntries(out, format)
+}
+
+// Subscribe delegates to the wrapped registry. If it does not report
+// mutations, fn is never called.
+func (w Wrapper) Subscribe(fn func(ev apis.RegistryEvent)) (unsubscribe func()) {
+	if s, ok := w.Registry.(apis.Subscriber); ok {
+		return s.Subscribe(fn)
+	}
+	return func() {}
+}
+
+// RegisterLazy delegates to the wrapped registry if it supports lazy entries.
+func (w Wrapper) RegisterLazy(t reflect.Type, fn func() (string, error)) error {
+	if l, ok := w.Registry.(apis.LazyRegistry); ok {
+		return l.RegisterLazy(t, fn)
+	}
+	return w.Unsupported()
+}
+
+// PendingLazy delegates to the wrapped registry if it supports lazy entries.
+func (w Wrapper) PendingLazy() []apis.LazyEntry {
+	if l, ok := w.Registry.(apis.LazyRegistry); ok {
+		return l.PendingLazy()
+	}
+	return nil
+}
+
+// Unregister delegates to the wrapped registry; it reports false if the
+// wrapped registry does not support removal.
+func (w Wrapper) Unregister(t reflect.Type) bool {
+	if u, ok := w.Registry.(apis.Unregisterer); ok {
+		return u.Unregister(t)
+	}
+	return false
+}
+
+// Freeze delegates to the wrapped registry if it can be frozen.
+func (w Wrapper) Freeze() {
+	if f, ok := w.Registry.(apis.Freezer); ok {
+		f.Freeze()
+	}
+}
+
+// Unsupported returns the error reported for writes to an optional interface
+// the wrapped registry lacks; it wraps errors.ErrUnsupported.
+func (w Wrapper) Unsupported() error {
+	return fmt.Errorf("rfx(registry): %T: %w", w.Registry, errors.ErrUnsupported)
+}
@@ -0,0 +1,68 @@
+/*
+   Copyright 2025 The DIRPX Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package registry_test
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"dirpx.dev/rfx/apis"
+	"dirpx.dev/rfx/config"
+	"dirpx.dev/rfx/registry"
+)
+
+func TestWrapper_FallsBackForPlainRegistries(t *testing.T) {
+	// The anonymous struct hides every optional interface of the inner registry.
+	plain := struct{ apis.Registry }{registry.New(config.DefaultConfig())}
+	if err := plain.Register(reflect.TypeOf(T1{}), "lib.a"); err != nil {
+		t.Fatal(err)
+	}
+	w := registry.Wrapper{Registry: plain, Config: config.DefaultConfig()}
+
+	// Reads fall back to Entries.
+	if got, ok := w.Snapshot().Lookup(reflect.TypeOf(&T1{})); !ok || got != "lib.a" {
+		t.Fatalf("Snapshot().Lookup = (%q,%v), want (lib.a,true)", got, ok)
+	}
+	if n := w.CountByPrefix("lib."); n != 1 {
+		t.Fatalf("CountByPrefix = %d, want 1", n)
+	}
+	var buf bytes.Buffer
+	if err := w.WriteEntries(&buf, "jsonl"); err != nil || !strings.Contains(buf.String(), "lib.a") {
+		t.Fatalf("WriteEntries = %v, %q", err, buf.String())
+	}
+	if _, ok := w.LookupDisplay(reflect.TypeOf(T1{})); ok {
+		t.Fatal("LookupDisplay should miss")
+	}
+	if p := w.PendingLazy(); p != nil {
+		t.Fatalf("PendingLazy = %+v, want nil", p)
+	}
+	w.Subscribe(func(apis.RegistryEvent) { t.Fatal("unexpected event") })()
+
+	// Writes are unsupported.
+	if err := w.RegisterDisplay(reflect.TypeOf(T2{}), "lib.b", "B"); !errors.Is(err, errors.ErrUnsupported) {
+		t.Fatalf("RegisterDisplay: want ErrUnsupported, got %v", err)
+	}
+	if err := w.RegisterLazy(reflect.TypeOf(T2{}), func() (string, error) { return "lib.b", nil }); !errors.Is(err, errors.ErrUnsupported) {
+		t.Fatalf("RegisterLazy: want ErrUnsupported, got %v", err)
+	}
+	if w.Unregister(reflect.TypeOf(T1{})) {
+		t.Fatal("Unregister reported true")
+	}
+}
@@ -0,0 +1,77 @@
+/*
+   Copyright 2025 The DIRPX Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package resolver_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"dirpx.dev/rfx/apis"
+	"dirpx.dev/rfx/resolver"
+)
+
+type tenantKey struct{}
+
+// tenantStrategy names values "<tenant>.entity" using the tenant in ctx and
+// falls through when there is none.
+type tenantStrategy struct{}
+
+func (tenantStrategy) TryResolve(any, apis.Config) (string, bool)              { return "", false }
+func (tenantStrategy) TryResolveType(reflect.Type, apis.Config) (string, bool) { return "", false }
+func (tenantStrategy) TryResolveCtx(ctx context.Context, _ any, _ apis.Config) (string, bool) {
+	tenant, ok := ctx.Value(tenantKey{}).(string)
+	if !ok {
+		return "", false
+	}
+	return tenant + ".entity", true
+}
+
+func TestResolveCtx_MixedStrategies(t *testing.T) {
+	conf := apis.Config{}
+	ctx := context.WithValue(context.Background(), tenantKey{}, "acme")
+
+	for name, res := range map[string]apis.Resolver{
+		"New":     resolver.New(tenantStrategy{}, fixedStrategy{name: "plain"}),
+		"NewSafe": resolver.NewSafe(tenantStrategy{}, fixedStrategy{name: "plain"}),
+		"Prefix":  resolver.WithPrefix(resolver.New(tenantStrategy{}, fixedStrategy{name: "plain"}), "p"),
+	} {
+		cr, ok := res.(apis.ContextResolver)
+		if !ok {
+			t.Fatalf("%s: resolver does not implement apis.ContextResolver", name)
+		}
+		want := "acme.entity"
+		if name == "Prefix" {
+			want = "p/acme.entity"
+		}
+		if got := cr.ResolveCtx(ctx, 1, conf); got != want {
+			t.Errorf("%s: ResolveCtx with tenant = %q, want %q", name, got, want)
+		}
+		// Without a tenant the context strategy falls through to the plain one.
+		want = "plain"
+		if name == "Prefix" {
+			want = "p/plain"
+		}
+		if got := cr.ResolveCtx(context.Background(), 1, conf); got != want {
+			t.Errorf("%s: ResolveCtx without tenant = %q, want %q", name, got, want)
+		}
+		// Plain Resolve never sees the context.
+		if got := res.Resolve(1, conf); got != want {
+			t.Errorf("%s: Resolve = %q, want %q", name, got, want)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+/*
+   Copyright 2025 The DIRPX Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package resolver
+
+import (
+	"reflect"
+
+	"dirpx.dev/rfx/apis"
+)
+
+// Fallback constructs an apis.Resolver that consults the given resolvers in
+// order and returns the first non-empty name. Nil resolvers are ignored.
+func Fallback(resolvers ...apis.Resolver) apis.Resolver {
+	out := make([]apis.Resolver, 0, len(resolvers))
+	for _, r := range resolvers {
+		if r != nil {
+			out = append(out, r)
+		}
+	}
+	return fallback{ress: out}
+}
+
+// fallback is an immutable, order-preserving resolver over other resolvers.
+type fallback struct {
+	ress []apis.Resolver
+}
+
+// Resolve returns the first non-empty name produced for v.
+func (r fallback) Resolve(v any, cfg apis.Config) string {
+	for _, res := range r.ress {
+		if name := res.Resolve(v, cfg); name != "" {
+			return name
+		}
+	}
+	return ""
+}
+
+// ResolveType returns the first non-empty name produced for t.
+func (r fallback) ResolveType(t reflect.Type, cfg apis.Config) string {
+	for _, res := range r.ress {
+		if name := res.ResolveType(t, cfg); name != "" {
+			return name
+		}
+	}
+	return ""
+}
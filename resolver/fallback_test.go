@@ -0,0 +1,71 @@
+/*
+   Copyright 2025 The DIRPX Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package resolver_test
+
+import (
+	"reflect"
+	"testing"
+
+	"dirpx.dev/rfx/apis"
+	"dirpx.dev/rfx/resolver"
+)
+
+// emptyStrategy handles everything with an empty name.
+type emptyStrategy struct{}
+
+func (emptyStrategy) TryResolve(any, apis.Config) (string, bool)              { return "", true }
+func (emptyStrategy) TryResolveType(reflect.Type, apis.Config) (string, bool) { return "", true }
+
+func TestFallback_FirstNonEmptyWins(t *testing.T) {
+	res := resolver.Fallback(
+		nil,
+		resolver.New(emptyStrategy{}),
+		resolver.New(fixedStrategy{name: "second"}),
+		resolver.New(fixedStrategy{name: "third"}),
+	)
+	conf := apis.Config{}
+
+	if got := res.Resolve(struct{}{}, conf); got != "second" {
+		t.Fatalf("Resolve: got %q, want %q", got, "second")
+	}
+	if got := res.ResolveType(reflect.TypeOf(0), conf); got != "second" {
+		t.Fatalf("ResolveType: got %q, want %q", got, "second")
+	}
+	if got := resolver.Fallback().Resolve(1, conf); got != "" {
+		t.Fatalf("empty Fallback: got %q, want empty", got)
+	}
+}
+
+func TestNewSkipEmpty_ContinuesPastEmpty(t *testing.T) {
+	conf := apis.Config{}
+
+	// The plain chain stops at the empty-but-handled result.
+	if got := resolver.New(emptyStrategy{}, fixedStrategy{name: "later"}).Resolve(1, conf); got != "" {
+		t.Fatalf("New: got %q, want empty", got)
+	}
+
+	res := resolver.NewSkipEmpty(nil, emptyStrategy{}, fixedStrategy{name: "later"})
+	if got := res.Resolve(1, conf); got != "later" {
+		t.Fatalf("Resolve: got %q, want %q", got, "later")
+	}
+	if got := res.ResolveType(reflect.TypeOf(0), conf); got != "later" {
+		t.Fatalf("ResolveType: got %q, want %q", got, "later")
+	}
+	if got := resolver.NewSkipEmpty(emptyStrategy{}).Resolve(1, conf); got != "" {
+		t.Fatalf("only empty: got %q, want empty", got)
+	}
+}
@@ -0,0 +1,123 @@
+/*
+   Copyright 2025 The DIRPX Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package resolver
+
+import (
+	"context"
+	"reflect"
+
+	"dirpx.dev/rfx/apis"
+)
+
+// PrefixSeparator separates the prefix added by WithPrefix from the name.
+const PrefixSeparator = "/"
+
+// WithPrefix decorates res so every non-empty name it resolves becomes
+// prefix + "/" + name. Empty names stay empty. An empty prefix or a nil res
+// returns res unchanged.
+func WithPrefix(res apis.Resolver, prefix string) apis.Resolver {
+	if prefix == "" || res == nil {
+		return res
+	}
+	return prefixed{res: res, prefix: prefix + PrefixSeparator}
+}
+
+// prefixed is an immutable resolver that prefixes the names of another.
+// It forwards apis.StrategyLister, apis.EmptySkipper and apis.SourceResolver
+// to res, so introspection sees the strategies behind the prefix.
+type prefixed struct {
+	res    apis.Resolver
+	prefix string
+}
+
+// Ensure prefixed implements apis.SourceResolver, apis.EmptySkipper and
+// apis.ContextResolver.
+var (
+	_ apis.SourceResolver  = prefixed{}
+	_ apis.EmptySkipper    = prefixed{}
+	_ apis.ContextResolver = prefixed{}
+)
+
+// Resolve returns the prefixed name of v, or "" if res resolves none.
+func (r prefixed) Resolve(v any, cfg apis.Config) string {
+	return r.apply(r.res.Resolve(v, cfg))
+}
+
+// ResolveType returns the prefixed name of t, or "" if res resolves none.
+func (r prefixed) ResolveType(t reflect.Type, cfg apis.Config) string {
+	return r.apply(r.res.ResolveType(t, cfg))
+}
+
+// ResolveCtx returns the prefixed name of v, passing ctx on if res is an
+// apis.ContextResolver.
+func (r prefixed) ResolveCtx(ctx context.Context, v any, cfg apis.Config) string {
+	if cr, ok := r.res.(apis.ContextResolver); ok {
+		return r.apply(cr.ResolveCtx(ctx, v, cfg))
+	}
+	return r.Resolve(v, cfg)
+}
+
+// Strategies returns the strategies of res, or nil if it does not implement
+// apis.StrategyLister.
+func (r prefixed) Strategies() []apis.Strategy {
+	if l, ok := r.res.(apis.StrategyLister); ok {
+		return l.Strategies()
+	}
+	return nil
+}
+
+// SkipsEmpty reports whether res skips handled-but-empty results.
+func (r prefixed) SkipsEmpty() bool {
+	e, ok := r.res.(apis.EmptySkipper)
+	return ok && e.SkipsEmpty()
+}
+
+// ResolveSource returns the prefixed name of v and the index of the strategy
+// of res that produced it, or -1 if res is not an apis.SourceResolver.
+func (r prefixed) ResolveSource(v any, cfg apis.Config) (string, int) {
+	if sr, ok := r.res.(apis.SourceResolver); ok {
+		name, i := sr.ResolveSource(v, cfg)
+		return r.apply(name), i
+	}
+	return r.Resolve(v, cfg), -1
+}
+
+// ResolveTypeSource is the reflect.Type variant of ResolveSource.
+func (r prefixed) ResolveTypeSource(t reflect.Type, cfg apis.Config) (string, int) {
+	if sr, ok := r.res.(apis.SourceResolver); ok {
+		name, i := sr.ResolveTypeSource(t, cfg)
+		return r.apply(name), i
+	}
+	return r.ResolveType(t, cfg), -1
+}
+
+// ResolveCtxSource is the context variant of ResolveSource.
+func (r prefixed) ResolveCtxSource(ctx context.Context, v any, cfg apis.Config) (string, int) {
+	if sr, ok := r.res.(apis.SourceResolver); ok {
+		name, i := sr.ResolveCtxSource(ctx, v, cfg)
+		return r.apply(name), i
+	}
+	return r.ResolveCtx(ctx, v, cfg), -1
+}
+
+// apply prefixes a non-empty name.
+func (r prefixed) apply(name string) string {
+	if name == "" {
+		return name
+	}
+	return r.prefix + name
+}
@@ -0,0 +1,67 @@
+/*
+   Copyright 2025 The DIRPX Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package resolver_test
+
+import (
+	"reflect"
+	"testing"
+
+	"dirpx.dev/rfx/apis"
+	"dirpx.dev/rfx/resolver"
+)
+
+func TestWithPrefix(t *testing.T) {
+	conf := apis.Config{}
+	res := resolver.WithPrefix(resolver.New(fixedStrategy{name: "pkg.T"}), "tenant-a")
+	if got := res.Resolve(1, conf); got != "tenant-a/pkg.T" {
+		t.Fatalf("Resolve: got %q, want tenant-a/pkg.T", got)
+	}
+	if got := res.ResolveType(reflect.TypeOf(0), conf); got != "tenant-a/pkg.T" {
+		t.Fatalf("ResolveType: got %q, want tenant-a/pkg.T", got)
+	}
+
+	if got := resolver.WithPrefix(resolver.New(emptyStrategy{}), "tenant-a").Resolve(1, conf); got != "" {
+		t.Fatalf("empty name: got %q, want empty", got)
+	}
+
+	inner := resolver.New(fixedStrategy{name: "pkg.T"})
+	if got := resolver.WithPrefix(inner, ""); !reflect.DeepEqual(got, inner) {
+		t.Fatalf("empty prefix should return the resolver unchanged, got %#v", got)
+	}
+	if got := resolver.WithPrefix(nil, "tenant-a"); got != nil {
+		t.Fatalf("nil resolver should stay nil, got %#v", got)
+	}
+}
+
+func TestWithPrefix_ForwardsStrategies(t *testing.T) {
+	a, b := emptyStrategy{}, fixedStrategy{name: "pkg.T"}
+	res := resolver.WithPrefix(resolver.NewSkipEmpty(a, b), "tenant-a")
+
+	sr, ok := res.(apis.SourceResolver)
+	if !ok {
+		t.Fatalf("%T does not implement apis.SourceResolver", res)
+	}
+	if got := sr.Strategies(); len(got) != 2 || got[0] != a || got[1] != b {
+		t.Fatalf("Strategies() = %v, want [a b]", got)
+	}
+	if name, i := sr.ResolveSource(1, apis.Config{}); name != "tenant-a/pkg.T" || i != 1 {
+		t.Fatalf("ResolveSource = %q, %d; want tenant-a/pkg.T, 1", name, i)
+	}
+	if e, ok := res.(apis.EmptySkipper); !ok || !e.SkipsEmpty() {
+		t.Fatal("SkipsEmpty should be forwarded")
+	}
+}
@@ -17,6 +17,7 @@
 package resolver
 
 import (
+	"context"
 	"reflect"
 
 	"dirpx.dev/rfx/apis"
@@ -25,40 +26,127 @@ import (
 // New constructs an apis.Resolver that tries the given strategies in order.
 // Nil strategies are ignored. The returned resolver is safe for concurrent use
 // provided strategies themselves are safe for concurrent TryResolve calls.
+// Strategies implementing apis.TypeResolvable and reporting false are skipped
+// by ResolveType.
 func New(strategies ...apis.Strategy) apis.Resolver {
 	// Filter out nils to avoid nil-interface panics on call sites.
 	out := make([]apis.Strategy, 0, len(strategies))
+	typ := make([]apis.Strategy, 0, len(strategies))
+	var idx []int
 	for _, s := range strategies {
-		if s != nil {
-			out = append(out, s)
+		if s == nil {
+			continue
 		}
+		out = append(out, s)
+		if tr, ok := s.(apis.TypeResolvable); ok && !tr.TypeResolvable() {
+			continue
+		}
+		typ = append(typ, s)
+		idx = append(idx, len(out)-1)
 	}
-	return chain{strats: out}
+	return chain{strats: out, typeStrats: typ, typeIdx: idx}
+}
+
+// NewSkipEmpty is like New, but a strategy that handles the input with an
+// empty name does not stop the chain: resolution continues until a strategy
+// yields a non-empty name. This keeps, for example, the reflect strategy
+// hiding a builtin as "" from shadowing a later fallback strategy.
+func NewSkipEmpty(strategies ...apis.Strategy) apis.Resolver {
+	c := New(strategies...).(chain)
+	c.skipEmpty = true
+	return c
 }
 
 // chain is an immutable, order-preserving resolver over a set of strategies.
 type chain struct {
+	// strats are all strategies, used for values.
 	strats []apis.Strategy
+	// typeStrats are the strategies able to resolve types, used for types.
+	typeStrats []apis.Strategy
+	// typeIdx maps each of typeStrats to its index in strats.
+	typeIdx []int
+	// skipEmpty treats an empty name from a handling strategy as a miss.
+	skipEmpty bool
+}
+
+// Ensure chain implements apis.SourceResolver, apis.EmptySkipper and
+// apis.ContextResolver.
+var (
+	_ apis.SourceResolver  = chain{}
+	_ apis.EmptySkipper    = chain{}
+	_ apis.ContextResolver = chain{}
+)
+
+// SkipsEmpty reports whether the chain was built with NewSkipEmpty.
+func (r chain) SkipsEmpty() bool { return r.skipEmpty }
+
+// Strategies returns a copy of the strategies in resolution order.
+func (r chain) Strategies() []apis.Strategy {
+	out := make([]apis.Strategy, len(r.strats))
+	copy(out, r.strats)
+	return out
 }
 
 // Resolve runs strategies in order until one handles the value.
 // Returns an empty string if no strategy produced a name.
 func (r chain) Resolve(v any, cfg apis.Config) string {
-	for _, s := range r.strats {
-		if name, ok := s.TryResolve(v, cfg); ok {
-			return name
+	name, _ := r.ResolveSource(v, cfg)
+	return name
+}
+
+// ResolveSource is Resolve that also returns the index of the strategy that
+// produced the name, or -1.
+func (r chain) ResolveSource(v any, cfg apis.Config) (string, int) {
+	for i, s := range r.strats {
+		if name, ok := s.TryResolve(v, cfg); ok && (name != "" || !r.skipEmpty) {
+			return name, i
 		}
 	}
-	return ""
+	return "", -1
 }
 
 // ResolveType runs strategies in order until one handles the type.
 // Returns an empty string if no strategy produced a name.
 func (r chain) ResolveType(t reflect.Type, cfg apis.Config) string {
-	for _, s := range r.strats {
-		if name, ok := s.TryResolveType(t, cfg); ok {
-			return name
+	name, _ := r.ResolveTypeSource(t, cfg)
+	return name
+}
+
+// ResolveTypeSource is ResolveType that also returns the index in Strategies
+// of the strategy that produced the name, or -1.
+func (r chain) ResolveTypeSource(t reflect.Type, cfg apis.Config) (string, int) {
+	for i, s := range r.typeStrats {
+		if name, ok := s.TryResolveType(t, cfg); ok && (name != "" || !r.skipEmpty) {
+			return name, r.typeIdx[i]
+		}
+	}
+	return "", -1
+}
+
+// ResolveCtx runs strategies in order until one handles the value, like
+// Resolve, passing ctx to strategies implementing apis.ContextStrategy.
+// Other strategies are called with TryResolve as usual.
+func (r chain) ResolveCtx(ctx context.Context, v any, cfg apis.Config) string {
+	name, _ := r.ResolveCtxSource(ctx, v, cfg)
+	return name
+}
+
+// ResolveCtxSource is ResolveCtx that also returns the index of the strategy
+// that produced the name, or -1.
+func (r chain) ResolveCtxSource(ctx context.Context, v any, cfg apis.Config) (string, int) {
+	for i, s := range r.strats {
+		if name, ok := tryResolveCtx(ctx, s, v, cfg); ok && (name != "" || !r.skipEmpty) {
+			return name, i
 		}
 	}
-	return ""
+	return "", -1
+}
+
+// tryResolveCtx calls s.TryResolveCtx if s is an apis.ContextStrategy and
+// s.TryResolve otherwise.
+func tryResolveCtx(ctx context.Context, s apis.Strategy, v any, cfg apis.Config) (string, bool) {
+	if cs, ok := s.(apis.ContextStrategy); ok {
+		return cs.TryResolveCtx(ctx, v, cfg)
+	}
+	return s.TryResolve(v, cfg)
 }
@@ -0,0 +1,119 @@
+/*
+   Copyright 2025 The DIRPX Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package resolver_test
+
+import (
+	"reflect"
+	"testing"
+
+	"dirpx.dev/rfx/apis"
+	"dirpx.dev/rfx/resolver"
+	"dirpx.dev/rfx/strategy"
+)
+
+// instanceOnlyStrategy needs a value and never resolves types.
+type instanceOnlyStrategy struct{ marked bool }
+
+func (instanceOnlyStrategy) TryResolve(any, apis.Config) (string, bool) { return "", false }
+func (s *instanceOnlyStrategy) TryResolveType(reflect.Type, apis.Config) (string, bool) {
+	if s.marked {
+		panic("TryResolveType called on a strategy marked as not type-resolvable")
+	}
+	return "", false
+}
+func (s *instanceOnlyStrategy) TypeResolvable() bool { return !s.marked }
+
+func TestStrategies_PreservesOrder(t *testing.T) {
+	a, b := fixedStrategy{name: "a"}, fixedStrategy{name: "b"}
+	for _, res := range []apis.Resolver{resolver.New(a, nil, b), resolver.NewSafe(a, b)} {
+		l, ok := res.(apis.StrategyLister)
+		if !ok {
+			t.Fatalf("%T does not implement apis.StrategyLister", res)
+		}
+		got := l.Strategies()
+		if len(got) != 2 || got[0] != a || got[1] != b {
+			t.Fatalf("%T.Strategies() = %v, want [a b]", res, got)
+		}
+	}
+}
+
+func TestResolveType_SkipsNonTypeResolvable(t *testing.T) {
+	res := resolver.New(&instanceOnlyStrategy{marked: true}, fixedStrategy{name: "fixed"})
+
+	if got := res.ResolveType(reflect.TypeOf(0), apis.Config{}); got != "fixed" {
+		t.Fatalf("ResolveType: got %q, want %q", got, "fixed")
+	}
+	// Skipped strategies still take part in value resolution and enumeration.
+	if n := len(res.(apis.StrategyLister).Strategies()); n != 2 {
+		t.Fatalf("Strategies: got %d, want 2", n)
+	}
+}
+
+// ---- Benchmarks ----
+
+func BenchmarkResolveType_TypeResolvableMarker(b *testing.B) {
+	conf := apis.Config{IncludeBuiltins: true, MaxUnwrap: 8, MapPreferElem: true}
+	typ := reflect.TypeOf(instanceOnlyStrategy{})
+
+	chains := []struct {
+		name string
+		res  apis.Resolver
+	}{
+		{"marked", resolver.New(
+			&instanceOnlyStrategy{marked: true},
+			&instanceOnlyStrategy{marked: true},
+			strategy.NewReflectStrategy(),
+		)},
+		{"unmarked", resolver.New(
+			&instanceOnlyStrategy{},
+			&instanceOnlyStrategy{},
+			strategy.NewReflectStrategy(),
+		)},
+	}
+	for _, c := range chains {
+		b.Run(c.name, func(b *testing.B) {
+			c.res.ResolveType(typ, conf) // warm-up cache
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				c.res.ResolveType(typ, conf)
+			}
+		})
+	}
+}
+
+func TestResolveSource_ReportsStrategyIndex(t *testing.T) {
+	skipped := &instanceOnlyStrategy{marked: true}
+	for _, res := range []apis.Resolver{
+		resolver.New(nil, skipped, fixedStrategy{name: "fixed"}),
+		resolver.NewSafe(panicStrategy{}, fixedStrategy{name: "fixed"}),
+	} {
+		sr, ok := res.(apis.SourceResolver)
+		if !ok {
+			t.Fatalf("%T does not implement apis.SourceResolver", res)
+		}
+		// Indices refer to Strategies, also for types that skip strategies.
+		if name, i := sr.ResolveSource(1, apis.Config{}); name != "fixed" || i != 1 {
+			t.Fatalf("%T.ResolveSource = %q, %d; want fixed, 1", res, name, i)
+		}
+		if name, i := sr.ResolveTypeSource(reflect.TypeOf(0), apis.Config{}); name != "fixed" || i != 1 {
+			t.Fatalf("%T.ResolveTypeSource = %q, %d; want fixed, 1", res, name, i)
+		}
+	}
+	if name, i := resolver.New(skipped).(apis.SourceResolver).ResolveSource(1, apis.Config{}); name != "" || i != -1 {
+		t.Fatalf("unresolved: got %q, %d; want \"\", -1", name, i)
+	}
+}
@@ -0,0 +1,166 @@
+/*
+   Copyright 2025 The DIRPX Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package resolver
+
+import (
+	"context"
+	"reflect"
+	"runtime/debug"
+	"sync"
+
+	"dirpx.dev/rfx/apis"
+)
+
+// maxPanics bounds the number of PanicInfo records kept by a safe resolver.
+const maxPanics = 64
+
+// PanicInfo describes a panic recovered from a strategy by a safe resolver.
+type PanicInfo struct {
+	// Strategy is the strategy that panicked.
+	Strategy apis.Strategy
+	// Value is the value passed to panic.
+	Value any
+	// Stack is the stack trace captured at recovery time.
+	Stack []byte
+}
+
+// SafeResolver is an apis.Resolver that isolates panics raised by strategies.
+type SafeResolver interface {
+	apis.Resolver
+	// Panics returns the most recently recovered panics, oldest first.
+	// At most 64 records are retained.
+	Panics() []PanicInfo
+}
+
+// NewSafe constructs a resolver like New, but wraps every TryResolve and
+// TryResolveType call in a recover: a panicking strategy is treated as
+// handled=false and the chain continues with the next strategy.
+//
+// Safe mode adds a deferred call per strategy invocation; use New on hot
+// paths where all strategies are trusted.
+func NewSafe(strategies ...apis.Strategy) SafeResolver {
+	return &safeChain{chain: New(strategies...).(chain)}
+}
+
+// safeChain is a chain that recovers from strategy panics.
+type safeChain struct {
+	chain
+	// mu guards panics.
+	mu sync.Mutex
+	// panics holds the most recent recovered panics, oldest first.
+	panics []PanicInfo
+}
+
+// Resolve runs strategies in order until one handles the value.
+// Strategies that panic are skipped.
+func (r *safeChain) Resolve(v any, cfg apis.Config) string {
+	name, _ := r.ResolveSource(v, cfg)
+	return name
+}
+
+// ResolveSource is Resolve that also returns the index of the strategy that
+// produced the name, or -1. Strategies that panic are skipped.
+func (r *safeChain) ResolveSource(v any, cfg apis.Config) (string, int) {
+	for i, s := range r.strats {
+		if name, ok := r.tryResolve(s, v, cfg); ok {
+			return name, i
+		}
+	}
+	return "", -1
+}
+
+// ResolveType runs strategies in order until one handles the type.
+// Strategies that panic are skipped.
+func (r *safeChain) ResolveType(t reflect.Type, cfg apis.Config) string {
+	name, _ := r.ResolveTypeSource(t, cfg)
+	return name
+}
+
+// ResolveTypeSource is ResolveType that also returns the index in Strategies
+// of the strategy that produced the name, or -1. Strategies that panic are
+// skipped.
+func (r *safeChain) ResolveTypeSource(t reflect.Type, cfg apis.Config) (string, int) {
+	for i, s := range r.typeStrats {
+		if name, ok := r.tryResolveType(s, t, cfg); ok {
+			return name, r.typeIdx[i]
+		}
+	}
+	return "", -1
+}
+
+// ResolveCtx is Resolve with ctx passed to apis.ContextStrategy strategies.
+// Strategies that panic are skipped.
+func (r *safeChain) ResolveCtx(ctx context.Context, v any, cfg apis.Config) string {
+	name, _ := r.ResolveCtxSource(ctx, v, cfg)
+	return name
+}
+
+// ResolveCtxSource is ResolveCtx that also returns the index of the strategy
+// that produced the name, or -1. Strategies that panic are skipped.
+func (r *safeChain) ResolveCtxSource(ctx context.Context, v any, cfg apis.Config) (string, int) {
+	for i, s := range r.strats {
+		if name, ok := r.tryResolveCtx(ctx, s, v, cfg); ok {
+			return name, i
+		}
+	}
+	return "", -1
+}
+
+// Panics returns a copy of the recorded panics, oldest first.
+func (r *safeChain) Panics() []PanicInfo {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	out := make([]PanicInfo, len(r.panics))
+	copy(out, r.panics)
+	return out
+}
+
+// tryResolve calls s.TryResolve, converting a panic into a miss.
+func (r *safeChain) tryResolve(s apis.Strategy, v any, cfg apis.Config) (string, bool) {
+	defer r.recoverFrom(s)
+	return s.TryResolve(v, cfg)
+}
+
+// tryResolveCtx calls the package-level tryResolveCtx, converting a panic into a miss.
+func (r *safeChain) tryResolveCtx(ctx context.Context, s apis.Strategy, v any, cfg apis.Config) (string, bool) {
+	defer r.recoverFrom(s)
+	return tryResolveCtx(ctx, s, v, cfg)
+}
+
+// tryResolveType calls s.TryResolveType, converting a panic into a miss.
+func (r *safeChain) tryResolveType(s apis.Strategy, t reflect.Type, cfg apis.Config) (string, bool) {
+	defer r.recoverFrom(s)
+	return s.TryResolveType(t, cfg)
+}
+
+// recoverFrom recovers a panic raised by s and records it.
+// It must be invoked directly via defer.
+func (r *safeChain) recoverFrom(s apis.Strategy) {
+	p := recover()
+	if p == nil {
+		return
+	}
+	info := PanicInfo{Strategy: s, Value: p, Stack: debug.Stack()}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(r.panics) == maxPanics {
+		copy(r.panics, r.panics[1:])
+		r.panics = r.panics[:maxPanics-1]
+	}
+	r.panics = append(r.panics, info)
+}
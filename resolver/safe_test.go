@@ -0,0 +1,78 @@
+/*
+   Copyright 2025 The DIRPX Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package resolver_test
+
+import (
+	"reflect"
+	"testing"
+
+	"dirpx.dev/rfx/apis"
+	"dirpx.dev/rfx/resolver"
+)
+
+// panicStrategy panics on every call.
+type panicStrategy struct{}
+
+func (panicStrategy) TryResolve(any, apis.Config) (string, bool) { panic("boom") }
+func (panicStrategy) TryResolveType(reflect.Type, apis.Config) (string, bool) {
+	panic("boom")
+}
+
+// fixedStrategy always handles with a fixed name.
+type fixedStrategy struct{ name string }
+
+func (s fixedStrategy) TryResolve(any, apis.Config) (string, bool) { return s.name, true }
+func (s fixedStrategy) TryResolveType(reflect.Type, apis.Config) (string, bool) {
+	return s.name, true
+}
+
+func TestNewSafe_SkipsPanickingStrategy(t *testing.T) {
+	res := resolver.NewSafe(panicStrategy{}, fixedStrategy{name: "fixed"})
+	conf := apis.Config{}
+
+	if got := res.Resolve(struct{}{}, conf); got != "fixed" {
+		t.Fatalf("Resolve: got %q, want %q", got, "fixed")
+	}
+	if got := res.ResolveType(reflect.TypeOf(0), conf); got != "fixed" {
+		t.Fatalf("ResolveType: got %q, want %q", got, "fixed")
+	}
+
+	panics := res.Panics()
+	if len(panics) != 2 {
+		t.Fatalf("Panics: got %d records, want 2", len(panics))
+	}
+	for _, p := range panics {
+		if p.Value != "boom" || len(p.Stack) == 0 {
+			t.Fatalf("unexpected PanicInfo: %+v", p)
+		}
+		if _, ok := p.Strategy.(panicStrategy); !ok {
+			t.Fatalf("PanicInfo.Strategy = %T, want panicStrategy", p.Strategy)
+		}
+	}
+}
+
+func TestNewSafe_PanicsAreBounded(t *testing.T) {
+	res := resolver.NewSafe(panicStrategy{})
+	for i := 0; i < 100; i++ {
+		if got := res.Resolve(i, apis.Config{}); got != "" {
+			t.Fatalf("Resolve: got %q, want empty", got)
+		}
+	}
+	if n := len(res.Panics()); n != 64 {
+		t.Fatalf("Panics: got %d records, want 64", n)
+	}
+}
@@ -17,7 +17,9 @@
 package rfx
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"reflect"
 	"sync"
 	"sync/atomic"
@@ -25,15 +27,19 @@ import (
 	"dirpx.dev/rfx/apis"
 	"dirpx.dev/rfx/builder"
 	"dirpx.dev/rfx/config"
+	"dirpx.dev/rfx/strategy"
+	uref "dirpx.dev/rfx/utils/reflect"
 )
 
 // init initializes the global res state.
 func init() {
-	// Initialize state with default cfg, reg, and res.
+	// Initialize state with default cfg, reg, and res. Embedders override the
+	// config with SetConfig from their own init; see "Initial configuration"
+	// in the package doc.
 	s := &state{cfg: config.DefaultConfig()}
 	b := builder.New()
-	s.reg = b.BuildRegistry(s.cfg, nil, nil)
-	s.res = b.BuildResolver(s.cfg, s.reg, nil, nil)
+	s.reg = buildRegistry(b, s.cfg, nil, nil, nil)
+	s.res = buildResolver(b, s.cfg, s.reg, nil, nil, nil)
 	s.bld = b
 	// Store the initial state atomically.
 	st.Store(s)
@@ -44,49 +50,351 @@ var (
 	ErrNilRegistry = errors.New("rfx: builder returned nil registry")
 	// ErrNilResolver is returned when a builder returns a nil resolver.
 	ErrNilResolver = errors.New("rfx: builder returned nil resolver")
+	// ErrConfigPinned is returned when the configuration is changed while
+	// pinned with PinConfig.
+	ErrConfigPinned = errors.New("rfx: config is pinned")
 )
 
 // Entity resolves the name of the provided value v using the global rfx res.
 // It uses the global rfx configuration and reg.
 // This is a convenience wrapper around the global res.
+//
+// Nil handling with the default chain:
+//   - Entity(nil) returns "": a nil interface carries no type.
+//   - Entity((*T)(nil)) resolves like any *T: the type is known even though the
+//     value is nil. If *T implements apis.Namer, EntityName is called on the nil
+//     receiver (a value-receiver method on T panics in that case); otherwise
+//     the registry and reflect strategies name T.
+//
+// Use EntityNilSafe to detect either case explicitly.
+// The configuration can be overridden per goroutine with WithConfigScope.
+//
+// If v is itself a reflect.Type, the type it represents is resolved, exactly
+// as EntityType(v) would, rather than reflect's internal *rtype. A nil
+// reflect.Type passed as v is a nil interface and therefore yields "".
 func Entity(v any) string {
-	s := st.Load()
-	return s.res.Resolve(v, s.cfg)
+	return entityIn(load(), v)
+}
+
+// EntityCtx resolves the name of v like Entity, passing ctx to the global rfx
+// res when it implements apis.ContextResolver (the default resolver does),
+// so strategies implementing apis.ContextStrategy can read request-scoped
+// values. For reflect.Type values it behaves exactly like Entity.
+func EntityCtx(ctx context.Context, v any) string {
+	s := load()
+	_, ok := s.res.(apis.ContextResolver)
+	if _, isType := v.(reflect.Type); !ok || isType {
+		return entityIn(s, v)
+	}
+	return resolverIn(s).(apis.ContextResolver).ResolveCtx(ctx, v, s.cfg)
+}
+
+// EntityName is a resolved entity name. Using it in signatures instead of
+// string documents that a value came from rfx and keeps arbitrary strings
+// from being passed where a resolved name is expected.
+type EntityName string
+
+// String returns the name as a plain string.
+func (n EntityName) String() string { return string(n) }
+
+// EntityTyped resolves the name of v like Entity and returns it as an EntityName.
+func EntityTyped(v any) EntityName {
+	return EntityName(Entity(v))
+}
+
+// EntityFast resolves the name of v using only the Namer fast path: it
+// returns v.EntityName() if v implements apis.Namer and "" otherwise.
+// The registry, reflect and any other configured strategies are skipped
+// entirely, so callers on hot paths must provide their own fallback.
+func EntityFast(v any) string {
+	if n, ok := v.(apis.Namer); ok {
+		return n.EntityName()
+	}
+	return ""
+}
+
+// EntityWithResolver resolves the name of v with res instead of the global
+// rfx res, using the current global (or scoped) configuration. It publishes
+// nothing and records neither traces nor unresolved types, so parallel tests
+// can inject a mock resolver per call without SetResolver clobbering each
+// other. A nil res falls back to the global rfx res.
+func EntityWithResolver(res apis.Resolver, v any) string {
+	if t, ok := v.(reflect.Type); ok {
+		return EntityTypeWithResolver(res, t)
+	}
+	s := load()
+	if res == nil {
+		res = s.res
+	}
+	return res.Resolve(v, s.cfg)
+}
+
+// EntityTypeWithResolver is the reflect.Type variant of EntityWithResolver.
+func EntityTypeWithResolver(res apis.Resolver, t reflect.Type) string {
+	s := load()
+	if res == nil {
+		res = s.res
+	}
+	return res.ResolveType(t, s.cfg)
+}
+
+// EntityNamer returns v.EntityName() directly. The type parameter statically
+// guarantees that v implements apis.Namer, so no snapshot is loaded and no
+// strategy chain runs; use it for domain types that always name themselves.
+func EntityNamer[T apis.Namer](v T) string {
+	return v.EntityName()
+}
+
+// EntitySet resolves every value in vs from a single snapshot and returns the
+// set of distinct non-empty names, e.g. to report which entity kinds appear
+// in a request.
+func EntitySet(vs ...any) map[string]struct{} {
+	s := load()
+	set := make(map[string]struct{}, len(vs))
+	for _, v := range vs {
+		if name := entityIn(s, v); name != "" {
+			set[name] = struct{}{}
+		}
+	}
+	return set
+}
+
+// entityIn implements Entity against the snapshot s.
+func entityIn(s *state, v any) string {
+	if t, ok := v.(reflect.Type); ok {
+		return entityTypeIn(s, t)
+	}
+	name := resolverIn(s).Resolve(v, s.cfg)
+	if strictConsistency.Load() {
+		checkConsistency(s, v, name)
+	}
+	return name
+}
+
+// EntityNilSafe resolves the name of v like Entity and additionally reports
+// whether v was a nil interface or a typed nil pointer. A typed nil pointer is
+// resolved by its type via ResolveType, so no method is ever invoked on a nil
+// receiver; a nil interface yields "".
+func EntityNilSafe(v any) (name string, wasNil bool) {
+	s := load()
+	if v == nil {
+		return "", true
+	}
+	if rv := reflect.ValueOf(v); rv.Kind() == reflect.Ptr && rv.IsNil() {
+		return s.res.ResolveType(rv.Type(), s.cfg), true
+	}
+	return s.res.Resolve(v, s.cfg), false
+}
+
+// EntityAs resolves the name of v like Entity and asserts v to I.
+// If v does not implement I (or is nil), ok is false and impl is the zero
+// value of I, but name is still resolved.
+func EntityAs[I any](v any) (name string, impl I, ok bool) {
+	impl, ok = v.(I)
+	return Entity(v), impl, ok
 }
 
 // EntityType resolves the name of the provided reflect.Type t using the global rfx res.
 // It uses the global rfx configuration and reg.
 // This is a convenience wrapper around the global res.
+// The configuration can be overridden per goroutine with WithConfigScope.
+//
+// A named interface type resolves to its own name ("pkg.Shape"): a
+// reflect.Type carries no dynamic type, so there is nothing else to resolve.
+// To name the concrete type behind an interface value, pass the value to
+// Entity, which always sees the dynamic type.
 func EntityType(t reflect.Type) string {
-	s := st.Load()
-	return s.res.ResolveType(t, s.cfg)
+	return entityTypeIn(load(), t)
+}
+
+// entityTypeIn implements EntityType against the snapshot s.
+func entityTypeIn(s *state, t reflect.Type) string {
+	return resolverIn(s).ResolveType(t, s.cfg)
+}
+
+// EntityTypeExact is a fast path of EntityType for types that are already
+// normalized: named types whose kind is not a container (pointer, slice,
+// array, map, chan). It consults the registry and then assembles "pkg.Type"
+// directly, skipping Normalize and the reflect cache, so bulk resolution of
+// many distinct types does not grow the shared cache. Other types, and all
+// calls while Trace is active, go through EntityType. BenchmarkEntityTypeExact
+// compares both paths.
+//
+// Its result is identical to EntityType under the default strategy order
+// (registry before reflect for types) without Config.GlobalPrefix, which
+// sends every call through EntityType; builders that add type-resolving
+// strategies or reorder them are bypassed, so use EntityType with those.
+func EntityTypeExact(t reflect.Type) string {
+	s := load()
+	if t == nil || tracer.Load() != nil || s.cfg.GlobalPrefix != "" || uref.IsContainerKind(t.Kind()) {
+		return entityTypeIn(s, t)
+	}
+	// The registry comes first for types, so a hit is final for any t.
+	if name, ok := s.reg.Lookup(t); ok {
+		return name
+	}
+	if !strategy.IsExactType(t) {
+		return entityTypeIn(s, t)
+	}
+	// Past the registry, t is either unresolved or named by reflection;
+	// TrackUnresolved records both.
+	recordUnresolved(t)
+	if s.cfg.DisableReflectFallback {
+		return ""
+	}
+	name, _ := strategy.ReflectName(t, s.cfg)
+	return name
+}
+
+// EntityMapParts resolves the key and element types of the map v separately,
+// as EntityType would, regardless of Config.MapPreferElem. For a
+// map[UserID]Session it returns the names of UserID and Session.
+// ok is false, and both names are "", if v is not a map.
+func EntityMapParts(v any) (keyName, valName string, ok bool) {
+	return EntityMapPartsType(reflect.TypeOf(v))
+}
+
+// EntityMapPartsType is the reflect.Type variant of EntityMapParts.
+func EntityMapPartsType(t reflect.Type) (keyName, valName string, ok bool) {
+	if t == nil || t.Kind() != reflect.Map {
+		return "", "", false
+	}
+	s := load()
+	return entityTypeIn(s, t.Key()), entityTypeIn(s, t.Elem()), true
+}
+
+// Resolve returns both the normalized reflect.Type of v and its resolved name,
+// computed from a single snapshot so the two always agree on the configuration.
+// The type is nil when v is nil or has no named type after normalization;
+// the name is still resolved in that case.
+func Resolve(v any) (reflect.Type, string) {
+	s := load()
+	var nt reflect.Type
+	if v != nil {
+		nt, _ = uref.Normalize(reflect.TypeOf(v), s.cfg)
+	}
+	return nt, s.res.Resolve(v, s.cfg)
 }
 
 // RegisterType adds a type-name mapping to the global rfx reg.
 // It uses the global rfx configuration.
 // This is a convenience wrapper around the global reg.
+// After Freeze it returns ErrFrozen (or panics, see FreezePanics).
 func RegisterType(t reflect.Type, name string) error {
+	if frozen.Load() {
+		return frozenErr()
+	}
 	return st.Load().reg.Register(t, name)
 }
 
+// RegisterDerived registers each type in the global rfx reg under the name the
+// reflect strategy derives for it with the global rfx configuration, turning
+// implicit reflection names into explicit registry entries.
+// Types that resolve to an empty name are skipped. It returns how many types
+// were newly registered; registration failures are joined into the error.
+func RegisterDerived(types ...reflect.Type) (int, error) {
+	if frozen.Load() {
+		return 0, frozenErr()
+	}
+	s := st.Load()
+	rs := strategy.NewReflectStrategy()
+
+	n := 0
+	var errs []error
+	for _, t := range types {
+		name, _ := rs.TryResolveType(t, s.cfg)
+		if name == "" {
+			continue
+		}
+		_, existed := s.reg.Lookup(t)
+		if err := s.reg.Register(t, name); err != nil {
+			errs = append(errs, fmt.Errorf("rfx: register %v as %q: %w", t, name, err))
+			continue
+		}
+		if !existed {
+			n++
+		}
+	}
+	return n, errors.Join(errs...)
+}
+
+// RegisterAll registers the type of each value in vs in the global rfx reg
+// under prefix + "." + its type name, where the type name is derived like the
+// reflect strategy does (normalized with the global rfx configuration, generic
+// parameters stripped) but without the package qualifier. An empty prefix
+// registers the bare type name. All values are attempted; failures are
+// joined into the returned error.
+func RegisterAll(prefix string, vs ...any) error {
+	if frozen.Load() {
+		return frozenErr()
+	}
+	s := st.Load()
+
+	var errs []error
+	for _, v := range vs {
+		base, err := uref.Normalize(reflect.TypeOf(v), s.cfg)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("rfx: register %T: %w", v, err))
+			continue
+		}
+		name := uref.StripTypeParams(base.Name())
+		if prefix != "" {
+			name = prefix + "." + name
+		}
+		if err := s.reg.Register(base, name); err != nil {
+			errs = append(errs, fmt.Errorf("rfx: register %v as %q: %w", base, name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
 // SetAll explicitly sets all global rfx state components.
 //
 // Nil arguments leave the corresponding component unchanged,
 // except for ext which is always replaced.
+// While the config is pinned (see PinConfig), cfg is ignored, the pinned
+// config is used for any rebuild, and the pin is kept.
 //
 // This is a convenience wrapper around the global state.
+// It panics with ErrNilRegistry or ErrNilResolver if the builder returns nil;
+// use TrySetAll to get an error instead.
 func SetAll(cfg *apis.Config, ext any, reg apis.Registry, res apis.Resolver, bld apis.Builder) {
 	buildMu.Lock()
 	defer buildMu.Unlock()
+	if frozenGuard() {
+		return
+	}
+	if err := setAllLocked(cfg, ext, reg, res, bld); err != nil {
+		panic(err)
+	}
+}
+
+// TrySetAll behaves like SetAll but returns ErrNilRegistry or ErrNilResolver
+// instead of panicking when the builder returns nil, leaving the previous
+// snapshot in place. After Freeze it returns ErrFrozen (or panics, see
+// FreezePanics).
+func TrySetAll(cfg *apis.Config, ext any, reg apis.Registry, res apis.Resolver, bld apis.Builder) error {
+	buildMu.Lock()
+	defer buildMu.Unlock()
+	if frozen.Load() {
+		return frozenErr()
+	}
+	return setAllLocked(cfg, ext, reg, res, bld)
+}
 
+// setAllLocked implements SetAll; buildMu must be held.
+func setAllLocked(cfg *apis.Config, ext any, reg apis.Registry, res apis.Resolver, bld apis.Builder) error {
 	// Load the old state.
 	old := st.Load()
 
-	// Configuration
+	// Configuration; a pinned config is never replaced.
 	ncfg := old.cfg
-	if cfg != nil {
+	if cfg != nil && !old.pcfg {
 		ncfg = *cfg
 	}
+	if old.noref {
+		ncfg.DisableReflectFallback = true
+	}
 
 	// Extension
 	next := ext
@@ -101,7 +409,7 @@ func SetAll(cfg *apis.Config, ext any, reg apis.Registry, res apis.Resolver, bld
 	nreg := reg
 	npreg := false
 	if nreg == nil {
-		nreg = nbld.BuildRegistry(ncfg, old.reg, next)
+		nreg = buildRegistry(nbld, ncfg, old.reg, old.ext, next)
 	} else {
 		npreg = true
 	}
@@ -110,31 +418,34 @@ func SetAll(cfg *apis.Config, ext any, reg apis.Registry, res apis.Resolver, bld
 	nres := res
 	npres := false
 	if nres == nil {
-		nres = nbld.BuildResolver(ncfg, nreg, old.res, next)
+		nres = buildResolver(nbld, ncfg, nreg, old.res, old.ext, next)
 	} else {
 		npres = true
 	}
 
 	// Ensure non-nil reg and res.
 	if nreg == nil {
-		panic(ErrNilRegistry)
+		return ErrNilRegistry
 	}
 	if nres == nil {
-		panic(ErrNilResolver)
+		return ErrNilResolver
 	}
 
 	// Store the new state atomically.
 	st.Store(
 		&state{
-			cfg:  ncfg,
-			ext:  next,
-			reg:  nreg,
-			res:  nres,
-			bld:  nbld,
-			preg: npreg,
-			pres: npres,
+			cfg:   ncfg,
+			ext:   next,
+			reg:   nreg,
+			res:   nres,
+			bld:   nbld,
+			preg:  npreg,
+			pres:  npres,
+			pcfg:  old.pcfg,
+			noref: old.noref,
 		},
 	)
+	return nil
 }
 
 // Config returns the global rfx configuration.
@@ -142,47 +453,146 @@ func Config() apis.Config {
 	return st.Load().cfg
 }
 
+// Current returns the global rfx configuration, reg and res from a single
+// snapshot load, so the three are always mutually consistent. Prefer it over
+// separate Config/Registry/Resolver calls when they must agree.
+func Current() (cfg apis.Config, reg apis.Registry, res apis.Resolver) {
+	s := st.Load()
+	return s.cfg, s.reg, s.res
+}
+
 // SetConfig sets the global rfx configuration to cfg.
 // It rebuilds the global reg and res using the new configuration.
 // This is a convenience wrapper around the global state.
+// It panics with ErrNilRegistry or ErrNilResolver if the builder returns nil;
+// use TrySetConfig to get an error instead. It is a no-op while the config is
+// pinned (see PinConfig).
 func SetConfig(cfg apis.Config) {
 	buildMu.Lock()
 	defer buildMu.Unlock()
+	if frozenGuard() {
+		return
+	}
+	setConfigLocked(cfg)
+}
+
+// TrySetConfig behaves like SetConfig but returns ErrNilRegistry or
+// ErrNilResolver instead of panicking when the builder returns nil, leaving
+// the previous snapshot in place. It returns ErrConfigPinned while the config
+// is pinned. After Freeze it returns ErrFrozen (or
+// panics, see FreezePanics).
+func TrySetConfig(cfg apis.Config) error {
+	buildMu.Lock()
+	defer buildMu.Unlock()
+	if frozen.Load() {
+		return frozenErr()
+	}
+	return trySetConfigLocked(cfg)
+}
+
+// CompareAndSetConfig publishes next like SetConfig, but only if the current
+// global rfx configuration equals expected, and reports whether it did.
+// Config reconciliation loops can read Config, derive next from it and retry
+// on false instead of clobbering a concurrent update. Configurations are
+// compared with apis.Config.Equal, so TypeAliases compare by content.
+// It returns false while the config is pinned, and after Freeze (or panics,
+// see FreezePanics).
+func CompareAndSetConfig(expected, next apis.Config) bool {
+	buildMu.Lock()
+	defer buildMu.Unlock()
+	if frozenGuard() {
+		return false
+	}
+	if old := st.Load(); old.pcfg || !old.cfg.Equal(expected) {
+		return false
+	}
+	setConfigLocked(next)
+	return true
+}
+
+// SetReflectFallback enables or disables the reflect fallback globally by
+// toggling Config.DisableReflectFallback and rebuilding non-pinned layers.
+// With the fallback disabled, the stock builder leaves the reflect strategy
+// out, so only explicitly named types (Namer, registry) resolve; everything
+// else resolves to "".
+//
+// The switch is kept in the global state like the pins: while it is off,
+// every config published by SetConfig, SetAll and friends gets
+// DisableReflectFallback set, until SetReflectFallback(true). It does nothing
+// while the config is pinned.
+func SetReflectFallback(enabled bool) {
+	buildMu.Lock()
+	defer buildMu.Unlock()
+	if frozenGuard() {
+		return
+	}
+	cfg := st.Load().cfg
+	cfg.DisableReflectFallback = !enabled
+	if err := rebuildLocked(cfg, !enabled); err != nil && !errors.Is(err, ErrConfigPinned) {
+		panic(err)
+	}
+}
 
+// setConfigLocked implements SetConfig; buildMu must be held.
+// It panics if the builder returns a nil registry or resolver, and does
+// nothing while the config is pinned.
+func setConfigLocked(cfg apis.Config) {
+	if err := trySetConfigLocked(cfg); err != nil && !errors.Is(err, ErrConfigPinned) {
+		panic(err)
+	}
+}
+
+// trySetConfigLocked implements TrySetConfig; buildMu must be held.
+func trySetConfigLocked(cfg apis.Config) error {
+	return rebuildLocked(cfg, st.Load().noref)
+}
+
+// rebuildLocked publishes cfg, with the reflect fallback switched off if
+// noref is set, and rebuilds non-pinned layers; buildMu must be held.
+func rebuildLocked(cfg apis.Config, noref bool) error {
 	// Load the old state.
 	old := st.Load()
+	if old.pcfg {
+		return ErrConfigPinned
+	}
+	if noref {
+		cfg.DisableReflectFallback = true
+	}
 	b := old.bld
 
 	// Build new nreg and res based on the new cfg and old state.
 	nreg := old.reg
 	if !old.preg {
-		nreg = b.BuildRegistry(cfg, old.reg, old.ext)
+		nreg = buildRegistry(b, cfg, old.reg, old.ext, old.ext)
 	}
 	nres := old.res
 	if !old.pres {
-		nres = b.BuildResolver(cfg, nreg, old.res, old.ext)
+		nres = buildResolver(b, cfg, nreg, old.res, old.ext, old.ext)
 	}
 
 	// Ensure non-nil nreg and res.
 	if nreg == nil {
-		panic(ErrNilRegistry)
+		return ErrNilRegistry
 	}
 	if nres == nil {
-		panic(ErrNilResolver)
+		return ErrNilResolver
 	}
 
 	// Store the new state atomically.
 	st.Store(
 		&state{
-			cfg:  cfg,
-			ext:  old.ext,
-			reg:  nreg,
-			res:  nres,
-			bld:  b,
-			preg: old.preg,
-			pres: old.pres,
+			cfg:   cfg,
+			ext:   old.ext,
+			reg:   nreg,
+			res:   nres,
+			bld:   b,
+			preg:  old.preg,
+			pres:  old.pres,
+			pcfg:  old.pcfg,
+			noref: noref,
 		},
 	)
+	return nil
 }
 
 // Registry returns the global rfx reg.
@@ -200,15 +610,18 @@ func SetRegistry(reg apis.Registry) {
 
 	buildMu.Lock()
 	defer buildMu.Unlock()
+	if frozenGuard() {
+		return
+	}
 
 	// Load the old state.
 	old := st.Load()
 	b := old.bld
-	
+
 	// Build new res based on the old cfg and new reg.
 	nres := old.res
 	if !old.pres {
-		nres = b.BuildResolver(old.cfg, reg, old.res, old.ext)
+		nres = buildResolver(b, old.cfg, reg, old.res, old.ext, old.ext)
 	}
 
 	// Ensure non-nil res.
@@ -219,13 +632,15 @@ func SetRegistry(reg apis.Registry) {
 	// Store the new state atomically.
 	st.Store(
 		&state{
-			cfg:  old.cfg,
-			ext:  old.ext,
-			reg:  reg,
-			res:  nres,
-			bld:  b,
-			preg: true,
-			pres: old.pres,
+			cfg:   old.cfg,
+			ext:   old.ext,
+			reg:   reg,
+			res:   nres,
+			bld:   b,
+			preg:  true,
+			pres:  old.pres,
+			pcfg:  old.pcfg,
+			noref: old.noref,
 		},
 	)
 }
@@ -245,6 +660,43 @@ func SetResolver(res apis.Resolver) {
 
 	buildMu.Lock()
 	defer buildMu.Unlock()
+	if frozenGuard() {
+		return
+	}
+
+	// Load the old state.
+	old := st.Load()
+
+	// Store the new state atomically.
+	st.Store(
+		&state{
+			cfg:   old.cfg,
+			ext:   old.ext,
+			reg:   old.reg,
+			res:   res,
+			bld:   old.bld,
+			preg:  old.preg,
+			pres:  true,
+			pcfg:  old.pcfg,
+			noref: old.noref,
+		},
+	)
+}
+
+// SetResolverAndRegistry installs res and reg together and pins both,
+// publishing a single snapshot so res never runs against the old reg.
+// If either argument is nil, nothing is changed.
+// This is a convenience wrapper around the global state.
+func SetResolverAndRegistry(res apis.Resolver, reg apis.Registry) {
+	if res == nil || reg == nil {
+		return
+	}
+
+	buildMu.Lock()
+	defer buildMu.Unlock()
+	if frozenGuard() {
+		return
+	}
 
 	// Load the old state.
 	old := st.Load()
@@ -252,13 +704,15 @@ func SetResolver(res apis.Resolver) {
 	// Store the new state atomically.
 	st.Store(
 		&state{
-			cfg:  old.cfg,
-			ext:  old.ext,
-			reg:  old.reg,
-			res:  res,
-			bld:  old.bld,
-			preg: old.preg,
-			pres: true,
+			cfg:   old.cfg,
+			ext:   old.ext,
+			reg:   reg,
+			res:   res,
+			bld:   old.bld,
+			preg:  true,
+			pres:  true,
+			pcfg:  old.pcfg,
+			noref: old.noref,
 		},
 	)
 }
@@ -277,6 +731,9 @@ func SetBuilder(b apis.Builder) {
 
 	buildMu.Lock()
 	defer buildMu.Unlock()
+	if frozenGuard() {
+		return
+	}
 
 	// Load the old state.
 	old := st.Load()
@@ -284,11 +741,11 @@ func SetBuilder(b apis.Builder) {
 	// Build new reg and res based on the new bld and old state.
 	nreg := old.reg
 	if !old.preg {
-		nreg = b.BuildRegistry(old.cfg, old.reg, old.ext)
+		nreg = buildRegistry(b, old.cfg, old.reg, old.ext, old.ext)
 	}
 	nres := old.res
 	if !old.pres {
-		nres = b.BuildResolver(old.cfg, nreg, old.res, old.ext)
+		nres = buildResolver(b, old.cfg, nreg, old.res, old.ext, old.ext)
 	}
 
 	// Ensure non-nil reg and res.
@@ -302,13 +759,15 @@ func SetBuilder(b apis.Builder) {
 	// Store the new state atomically.
 	st.Store(
 		&state{
-			cfg:  old.cfg,
-			ext:  old.ext,
-			reg:  nreg,
-			res:  nres,
-			bld:  b,
-			preg: old.preg,
-			pres: old.pres,
+			cfg:   old.cfg,
+			ext:   old.ext,
+			reg:   nreg,
+			res:   nres,
+			bld:   b,
+			preg:  old.preg,
+			pres:  old.pres,
+			pcfg:  old.pcfg,
+			noref: old.noref,
 		},
 	)
 }
@@ -317,6 +776,9 @@ func SetBuilder(b apis.Builder) {
 func SetExt[T any](ext T) {
 	buildMu.Lock()
 	defer buildMu.Unlock()
+	if frozenGuard() {
+		return
+	}
 
 	// Load the old state.
 	old := st.Load()
@@ -325,11 +787,11 @@ func SetExt[T any](ext T) {
 	// Build new reg and res based on the new ext and old state.
 	nreg := old.reg
 	if !old.preg {
-		nreg = b.BuildRegistry(old.cfg, old.reg, ext)
+		nreg = buildRegistry(b, old.cfg, old.reg, old.ext, ext)
 	}
 	nres := old.res
 	if !old.pres {
-		nres = b.BuildResolver(old.cfg, nreg, old.res, ext)
+		nres = buildResolver(b, old.cfg, nreg, old.res, old.ext, ext)
 	}
 
 	// Ensure non-nil reg and res.
@@ -343,13 +805,15 @@ func SetExt[T any](ext T) {
 	// Store the new state atomically.
 	st.Store(
 		&state{
-			cfg:  old.cfg,
-			ext:  ext,
-			reg:  nreg,
-			res:  nres,
-			bld:  b,
-			preg: old.preg,
-			pres: old.pres,
+			cfg:   old.cfg,
+			ext:   ext,
+			reg:   nreg,
+			res:   nres,
+			bld:   b,
+			preg:  old.preg,
+			pres:  old.pres,
+			pcfg:  old.pcfg,
+			noref: old.noref,
 		},
 	)
 }
@@ -369,6 +833,9 @@ func IsRegistryPinned() bool {
 func PinRegistry() {
 	buildMu.Lock()
 	defer buildMu.Unlock()
+	if frozenGuard() {
+		return
+	}
 
 	// Load the old state.
 	old := st.Load()
@@ -376,13 +843,15 @@ func PinRegistry() {
 	// Store the new state atomically.
 	st.Store(
 		&state{
-			cfg:  old.cfg,
-			ext:  old.ext,
-			reg:  old.reg,
-			res:  old.res,
-			bld:  old.bld,
-			preg: true,
-			pres: old.pres,
+			cfg:   old.cfg,
+			ext:   old.ext,
+			reg:   old.reg,
+			res:   old.res,
+			bld:   old.bld,
+			preg:  true,
+			pres:  old.pres,
+			pcfg:  old.pcfg,
+			noref: old.noref,
 		},
 	)
 }
@@ -391,6 +860,9 @@ func PinRegistry() {
 func UnpinRegistry() {
 	buildMu.Lock()
 	defer buildMu.Unlock()
+	if frozenGuard() {
+		return
+	}
 
 	// Load the old state.
 	old := st.Load()
@@ -398,13 +870,15 @@ func UnpinRegistry() {
 	// Store the new state atomically.
 	st.Store(
 		&state{
-			cfg:  old.cfg,
-			ext:  old.ext,
-			reg:  old.reg,
-			res:  old.res,
-			bld:  old.bld,
-			preg: false,
-			pres: old.pres,
+			cfg:   old.cfg,
+			ext:   old.ext,
+			reg:   old.reg,
+			res:   old.res,
+			bld:   old.bld,
+			preg:  false,
+			pres:  old.pres,
+			pcfg:  old.pcfg,
+			noref: old.noref,
 		},
 	)
 }
@@ -418,6 +892,9 @@ func IsResolverPinned() bool {
 func PinResolver() {
 	buildMu.Lock()
 	defer buildMu.Unlock()
+	if frozenGuard() {
+		return
+	}
 
 	// Load the old state.
 	old := st.Load()
@@ -425,13 +902,15 @@ func PinResolver() {
 	// Store the new state atomically.
 	st.Store(
 		&state{
-			cfg:  old.cfg,
-			ext:  old.ext,
-			reg:  old.reg,
-			res:  old.res,
-			bld:  old.bld,
-			preg: old.preg,
-			pres: true,
+			cfg:   old.cfg,
+			ext:   old.ext,
+			reg:   old.reg,
+			res:   old.res,
+			bld:   old.bld,
+			preg:  old.preg,
+			pres:  true,
+			pcfg:  old.pcfg,
+			noref: old.noref,
 		},
 	)
 }
@@ -440,6 +919,101 @@ func PinResolver() {
 func UnpinResolver() {
 	buildMu.Lock()
 	defer buildMu.Unlock()
+	if frozenGuard() {
+		return
+	}
+
+	// Load the old state.
+	old := st.Load()
+
+	// Store the new state atomically.
+	st.Store(
+		&state{
+			cfg:   old.cfg,
+			ext:   old.ext,
+			reg:   old.reg,
+			res:   old.res,
+			bld:   old.bld,
+			preg:  old.preg,
+			pres:  false,
+			pcfg:  old.pcfg,
+			noref: old.noref,
+		},
+	)
+}
+
+// IsConfigPinned returns whether the global rfx configuration is pinned.
+func IsConfigPinned() bool {
+	return st.Load().pcfg
+}
+
+// PinConfig makes the global rfx configuration immutable: until
+// UnpinConfig, SetConfig, SetReflectFallback and other config setters are
+// no-ops, TrySetConfig returns ErrConfigPinned and CompareAndSetConfig
+// returns false. Builder and ext changes, including SetAll, keep rebuilding
+// with the pinned config and leave the pin in place.
+func PinConfig() {
+	setConfigPin(true)
+}
+
+// UnpinConfig makes the global rfx configuration mutable again.
+func UnpinConfig() {
+	setConfigPin(false)
+}
+
+// setConfigPin sets the config pin flag to pinned.
+func setConfigPin(pinned bool) {
+	buildMu.Lock()
+	defer buildMu.Unlock()
+	if frozenGuard() {
+		return
+	}
+
+	// Load the old state.
+	old := st.Load()
+
+	// Store the new state atomically.
+	st.Store(
+		&state{
+			cfg:   old.cfg,
+			ext:   old.ext,
+			reg:   old.reg,
+			res:   old.res,
+			bld:   old.bld,
+			preg:  old.preg,
+			pres:  old.pres,
+			pcfg:  pinned,
+			noref: old.noref,
+		},
+	)
+}
+
+// IsFullyPinned returns whether the global rfx config, reg and res are all
+// pinned.
+func IsFullyPinned() bool {
+	s := st.Load()
+	return s.preg && s.pres && s.pcfg
+}
+
+// PinAll pins the global rfx config, reg and res in a single snapshot swap,
+// so readers never observe only some of them pinned.
+func PinAll() {
+	setPins(true)
+}
+
+// UnpinAll unpins the global rfx config, reg and res in a single snapshot
+// swap.
+func UnpinAll() {
+	setPins(false)
+}
+
+// setPins sets all pin flags to pinned in one snapshot swap.
+func setPins(pinned bool) {
+	buildMu.Lock()
+	defer buildMu.Unlock()
+	if frozenGuard() {
+		return
+	}
 
 	// Load the old state.
 	old := st.Load()
@@ -447,17 +1021,37 @@ func UnpinResolver() {
 	// Store the new state atomically.
 	st.Store(
 		&state{
-			cfg:  old.cfg,
-			ext:  old.ext,
-			reg:  old.reg,
-			res:  old.res,
-			bld:  old.bld,
-			preg: old.preg,
-			pres: false,
+			cfg:   old.cfg,
+			ext:   old.ext,
+			reg:   old.reg,
+			res:   old.res,
+			bld:   old.bld,
+			preg:  pinned,
+			pres:  pinned,
+			pcfg:  pinned,
+			noref: old.noref,
 		},
 	)
 }
 
+// buildRegistry builds a registry with b, passing prevExt through when b
+// implements apis.PrevExtBuilder.
+func buildRegistry(b apis.Builder, cfg apis.Config, prev apis.Registry, prevExt, ext any) apis.Registry {
+	if pb, ok := b.(apis.PrevExtBuilder); ok {
+		return pb.BuildRegistryWithPrevExt(cfg, prev, prevExt, ext)
+	}
+	return b.BuildRegistry(cfg, prev, ext)
+}
+
+// buildResolver builds a resolver with b, passing prevExt through when b
+// implements apis.PrevExtBuilder.
+func buildResolver(b apis.Builder, cfg apis.Config, reg apis.Registry, prev apis.Resolver, prevExt, ext any) apis.Resolver {
+	if pb, ok := b.(apis.PrevExtBuilder); ok {
+		return pb.BuildResolverWithPrevExt(cfg, reg, prev, prevExt, ext)
+	}
+	return b.BuildResolver(cfg, reg, prev, ext)
+}
+
 // buildMu serializes writers (reconfigurations/swaps) so we never publish
 // partially-built snapshots.
 var buildMu sync.Mutex
@@ -483,4 +1077,9 @@ type state struct {
 	preg bool
 	// pres indicates whether the res is pinned (immutable).
 	pres bool
+	// pcfg indicates whether the cfg is pinned (immutable).
+	pcfg bool
+	// noref indicates whether SetReflectFallback switched the reflect
+	// fallback off, which forces cfg.DisableReflectFallback on rebuilds.
+	noref bool
 }
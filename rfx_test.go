@@ -1,6 +1,7 @@
 package rfx
 
 import (
+	"errors"
 	"reflect"
 	"runtime"
 	"sync"
@@ -8,6 +9,9 @@ import (
 	"time"
 
 	apis "dirpx.dev/rfx/apis"
+	"dirpx.dev/rfx/builder"
+	"dirpx.dev/rfx/config"
+	"dirpx.dev/rfx/registry"
 )
 
 // ---------------------- Helpers ----------------------
@@ -307,6 +311,93 @@ func TestUnpin_Allows_Rebuild_After(t *testing.T) {
 	}
 }
 
+func TestPinAll_UnpinAll(t *testing.T) {
+	b := &mockBuilder{}
+	resetWithBuilder(t, b, apis.Config{IncludeBuiltins: false, MapPreferElem: true, MaxUnwrap: 8}, nil)
+
+	before := st.Load()
+	PinAll()
+	if !IsFullyPinned() || !IsRegistryPinned() || !IsResolverPinned() || !IsConfigPinned() {
+		t.Fatalf("PinAll should pin the config and both layers")
+	}
+	if s := st.Load(); s.reg != before.reg || s.res != before.res {
+		t.Fatalf("PinAll must not rebuild layers")
+	}
+
+	reg1, res1 := Registry(), Resolver()
+	UnpinConfig()
+	if IsFullyPinned() {
+		t.Fatalf("IsFullyPinned with the config unpinned should be false")
+	}
+	SetConfig(apis.Config{IncludeBuiltins: true, MapPreferElem: false, MaxUnwrap: 4})
+	if Registry() != reg1 || Resolver() != res1 {
+		t.Fatalf("pinned layers should not rebuild on SetConfig")
+	}
+
+	PinRegistry()
+	UnpinResolver()
+	if IsFullyPinned() {
+		t.Fatalf("IsFullyPinned with only the registry pinned should be false")
+	}
+
+	PinConfig()
+	UnpinAll()
+	if IsRegistryPinned() || IsResolverPinned() || IsConfigPinned() {
+		t.Fatalf("UnpinAll should unpin the config and both layers")
+	}
+}
+
+func TestPinConfig(t *testing.T) {
+	b := &mockBuilder{}
+	base := apis.Config{IncludeBuiltins: false, MapPreferElem: true, MaxUnwrap: 8}
+	resetWithBuilder(t, b, base, nil)
+
+	PinConfig()
+	if !IsConfigPinned() {
+		t.Fatalf("PinConfig should pin the config")
+	}
+	before := st.Load()
+
+	other := apis.Config{IncludeBuiltins: true, MaxUnwrap: 4}
+	SetConfig(other)
+	SetReflectFallback(false)
+	if s := st.Load(); s != before {
+		t.Fatalf("SetConfig while pinned must be a no-op")
+	}
+	if err := TrySetConfig(other); !errors.Is(err, ErrConfigPinned) {
+		t.Fatalf("TrySetConfig err = %v, want ErrConfigPinned", err)
+	}
+	if CompareAndSetConfig(base, other) {
+		t.Fatalf("CompareAndSetConfig should fail while pinned")
+	}
+
+	// Builder rebuilds keep the pinned config and the pin itself.
+	SetBuilder(&mockBuilder{})
+	if !reflect.DeepEqual(Config(), base) || !IsConfigPinned() {
+		t.Fatalf("SetBuilder changed the pinned config: %+v pinned=%v", Config(), IsConfigPinned())
+	}
+
+	UnpinConfig()
+	if IsConfigPinned() {
+		t.Fatalf("UnpinConfig should unpin the config")
+	}
+	SetConfig(other)
+	if !reflect.DeepEqual(Config(), other) {
+		t.Fatalf("SetConfig after UnpinConfig = %+v, want %+v", Config(), other)
+	}
+
+	// SetAll keeps both the pin and the pinned config.
+	PinConfig()
+	defer UnpinConfig()
+	resetWithBuilder(t, b, base, nil)
+	if !IsConfigPinned() || !reflect.DeepEqual(Config(), other) {
+		t.Fatalf("SetAll should keep the pinned config: %+v pinned=%v", Config(), IsConfigPinned())
+	}
+	if err := TrySetAll(&base, nil, nil, nil, nil); err != nil || !reflect.DeepEqual(Config(), other) {
+		t.Fatalf("TrySetAll should keep the pinned config: %+v err=%v", Config(), err)
+	}
+}
+
 func TestEntity_Concurrent_With_SetConfig(t *testing.T) {
 	b := &mockBuilder{}
 	resetWithBuilder(t, b, apis.Config{IncludeBuiltins: false, MapPreferElem: true, MaxUnwrap: 8}, nil)
@@ -342,3 +433,609 @@ func TestEntity_Concurrent_With_SetConfig(t *testing.T) {
 	wg.Wait()
 	<-done
 }
+
+type derivedA struct{}
+type derivedB struct{}
+
+func TestRegisterDerived_RegistersReflectNames(t *testing.T) {
+	resetWithBuilder(t, builder.New(), config.DefaultConfig(), nil)
+	Registry().Reset()
+
+	types := []reflect.Type{
+		reflect.TypeOf(derivedA{}),
+		reflect.TypeOf(&derivedB{}),
+		reflect.TypeOf(struct{}{}), // anonymous -> resolves to "" and is skipped
+	}
+	n, err := RegisterDerived(types...)
+	if err != nil {
+		t.Fatalf("RegisterDerived: unexpected error: %v", err)
+	}
+	if n != 2 {
+		t.Fatalf("RegisterDerived: n = %d, want 2", n)
+	}
+	if got, ok := Registry().Lookup(reflect.TypeOf(derivedB{})); !ok || got != "rfx.derivedB" {
+		t.Fatalf("Lookup(derivedB): got (%q,%v), want (rfx.derivedB,true)", got, ok)
+	}
+
+	// Re-registering is idempotent and not counted again.
+	n, err = RegisterDerived(types...)
+	if err != nil || n != 0 {
+		t.Fatalf("RegisterDerived again: got (%d,%v), want (0,nil)", n, err)
+	}
+}
+
+func TestRegisterDerived_Conflict(t *testing.T) {
+	resetWithBuilder(t, builder.New(), config.DefaultConfig(), nil)
+	Registry().Reset()
+
+	if err := RegisterType(reflect.TypeOf(derivedA{}), "explicit.a"); err != nil {
+		t.Fatalf("RegisterType: %v", err)
+	}
+	n, err := RegisterDerived(reflect.TypeOf(derivedA{}), reflect.TypeOf(derivedB{}))
+	if !errors.Is(err, registry.ErrConflictingRegistration) {
+		t.Fatalf("want ErrConflictingRegistration, got %v", err)
+	}
+	if n != 1 {
+		t.Fatalf("n = %d, want 1", n)
+	}
+}
+
+// taggedBuilder tags the registry and resolver it builds with cfg.MaxUnwrap.
+type taggedBuilder struct{}
+
+func (taggedBuilder) BuildRegistry(cfg apis.Config, _ apis.Registry, _ any) apis.Registry {
+	return newMockRegistry(itoa(cfg.MaxUnwrap))
+}
+
+func (taggedBuilder) BuildResolver(cfg apis.Config, _ apis.Registry, _ apis.Resolver, _ any) apis.Resolver {
+	return &mockResolver{id: itoa(cfg.MaxUnwrap)}
+}
+
+func TestCurrent_IsConsistent_Under_SetConfig(t *testing.T) {
+	resetWithBuilder(t, taggedBuilder{}, apis.Config{MaxUnwrap: 1}, nil)
+	defer resetWithBuilder(t, builder.New(), config.DefaultConfig(), nil)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 200; i++ {
+			SetConfig(apis.Config{MaxUnwrap: 1 + i%7})
+		}
+	}()
+
+	readers := runtime.GOMAXPROCS(0) * 2
+	var wg sync.WaitGroup
+	wg.Add(readers)
+	errCh := make(chan string, readers)
+	for i := 0; i < readers; i++ {
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 2000; j++ {
+				cfg, reg, res := Current()
+				want := itoa(cfg.MaxUnwrap)
+				if reg.(*mockRegistry).id != want || res.(*mockResolver).id != want {
+					errCh <- "torn snapshot: cfg=" + want + " reg=" + reg.(*mockRegistry).id + " res=" + res.(*mockResolver).id
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	<-done
+	close(errCh)
+	for e := range errCh {
+		t.Fatal(e)
+	}
+}
+
+type resolveNamed struct{}
+
+func (resolveNamed) EntityName() string { return "resolve.named" }
+
+func TestResolve_ReturnsNormalizedTypeAndName(t *testing.T) {
+	resetWithBuilder(t, builder.New(), config.DefaultConfig(), nil)
+	Registry().Reset()
+
+	typ, name := Resolve(&[]derivedA{})
+	if typ != reflect.TypeOf(derivedA{}) {
+		t.Fatalf("type = %v, want %v", typ, reflect.TypeOf(derivedA{}))
+	}
+	if want := EntityType(reflect.TypeOf(derivedA{})); name != want {
+		t.Fatalf("name = %q, want %q", name, want)
+	}
+
+	typ, name = Resolve(resolveNamed{})
+	if typ != reflect.TypeOf(resolveNamed{}) || name != "resolve.named" {
+		t.Fatalf("Resolve(namer) = (%v, %q)", typ, name)
+	}
+
+	if typ, _ := Resolve(struct{}{}); typ != nil {
+		t.Fatalf("anonymous type should normalize to nil, got %v", typ)
+	}
+	if typ, _ := Resolve(nil); typ != nil {
+		t.Fatalf("nil value should yield nil type, got %v", typ)
+	}
+}
+
+type nilOrder struct{}
+
+func (nilOrder) EntityName() string { return "nil.order" }
+
+func TestEntityNilSafe(t *testing.T) {
+	resetWithBuilder(t, builder.New(), config.DefaultConfig(), nil)
+	Registry().Reset()
+
+	if name, wasNil := EntityNilSafe(nil); name != "" || !wasNil {
+		t.Fatalf("nil: got (%q,%v), want (\"\",true)", name, wasNil)
+	}
+
+	// Typed nil: resolved by type, the value-receiver EntityName is not called.
+	want := EntityType(reflect.TypeOf(nilOrder{}))
+	if name, wasNil := EntityNilSafe((*nilOrder)(nil)); name != want || !wasNil {
+		t.Fatalf("typed nil: got (%q,%v), want (%q,true)", name, wasNil, want)
+	}
+
+	if name, wasNil := EntityNilSafe(nilOrder{}); name != "nil.order" || wasNil {
+		t.Fatalf("value: got (%q,%v), want (\"nil.order\",false)", name, wasNil)
+	}
+	if name, wasNil := EntityNilSafe(&nilOrder{}); name != "nil.order" || wasNil {
+		t.Fatalf("pointer: got (%q,%v), want (\"nil.order\",false)", name, wasNil)
+	}
+}
+
+func TestSetConfig_WithoutMigration_DropsRuntimeRegistrations(t *testing.T) {
+	resetWithBuilder(t, builder.New(builder.WithoutMigration()), config.DefaultConfig(), nil)
+	defer resetWithBuilder(t, builder.New(), config.DefaultConfig(), nil)
+	Registry().Reset()
+
+	typ := reflect.TypeOf(derivedA{})
+	if err := RegisterType(typ, "runtime.a"); err != nil {
+		t.Fatalf("RegisterType: %v", err)
+	}
+	SetConfig(config.NewConfig(config.WithMaxUnwrap(4)))
+
+	if _, ok := Registry().Lookup(typ); ok {
+		t.Fatal("runtime registration should not survive a rebuild without migration")
+	}
+}
+
+type unregisteredType struct{}
+
+func TestSetReflectFallback(t *testing.T) {
+	defer Capture().Restore()
+	resetWithBuilder(t, builder.New(), config.DefaultConfig(), nil)
+	Registry().Reset()
+	if err := RegisterType(reflect.TypeOf(derivedA{}), "explicit.a"); err != nil {
+		t.Fatalf("RegisterType: %v", err)
+	}
+
+	SetReflectFallback(false)
+	if !Config().DisableReflectFallback {
+		t.Fatal("DisableReflectFallback not stored in config")
+	}
+	if got := Entity(unregisteredType{}); got != "" {
+		t.Fatalf("unregistered with fallback disabled: got %q, want empty", got)
+	}
+	if got := Entity(derivedA{}); got != "explicit.a" {
+		t.Fatalf("registered: got %q, want %q", got, "explicit.a")
+	}
+	if got := Entity(nilOrder{}); got != "nil.order" {
+		t.Fatalf("namer: got %q, want %q", got, "nil.order")
+	}
+
+	// The switch survives configs that do not mention it.
+	SetConfig(config.DefaultConfig())
+	if got := Entity(unregisteredType{}); got != "" || !Config().DisableReflectFallback {
+		t.Fatalf("after SetConfig: got %q, DisableReflectFallback=%v", got, Config().DisableReflectFallback)
+	}
+	cfg := config.DefaultConfig()
+	SetAll(&cfg, nil, nil, nil, nil)
+	if got := Entity(unregisteredType{}); got != "" {
+		t.Fatalf("after SetAll: got %q, want empty", got)
+	}
+
+	SetReflectFallback(true)
+	if got := Entity(unregisteredType{}); got != "rfx.unregisteredType" {
+		t.Fatalf("fallback re-enabled: got %q", got)
+	}
+}
+
+func TestEntityAs(t *testing.T) {
+	defer Capture().Restore()
+	resetWithBuilder(t, builder.New(), config.DefaultConfig(), nil)
+
+	name, n, ok := EntityAs[apis.Namer](nilOrder{})
+	if !ok || name != "nil.order" || n.EntityName() != "nil.order" {
+		t.Fatalf("EntityAs[Namer](nilOrder) = (%q,%v,%v)", name, n, ok)
+	}
+
+	name, d, ok := EntityAs[apis.Describer](nilOrder{})
+	if ok || d != nil || name != "nil.order" {
+		t.Fatalf("EntityAs[Describer](nilOrder) = (%q,%v,%v), want (nil.order,nil,false)", name, d, ok)
+	}
+
+	if name, _, ok := EntityAs[apis.Namer](nil); ok || name != "" {
+		t.Fatalf("EntityAs(nil) = (%q,%v), want ('',false)", name, ok)
+	}
+}
+
+func TestEntity_ReflectTypeValue(t *testing.T) {
+	defer Capture().Restore()
+	resetWithBuilder(t, builder.New(), config.DefaultConfig(), nil)
+	Registry().Reset()
+	_ = RegisterType(reflect.TypeOf(derivedA{}), "meta.a")
+
+	typ := reflect.TypeOf(derivedA{})
+	if got, want := Entity(typ), EntityType(typ); got != want || got != "meta.a" {
+		t.Fatalf("Entity(reflect.Type) = %q, want %q", got, want)
+	}
+	if got := Entity(reflect.TypeOf(unregisteredType{})); got != "rfx.unregisteredType" {
+		t.Fatalf("Entity(reflect.Type) via reflect = %q", got)
+	}
+
+	var nilType reflect.Type
+	if got := Entity(nilType); got != "" {
+		t.Fatalf("Entity(nil reflect.Type) = %q, want empty", got)
+	}
+}
+
+func TestSetResolverAndRegistry_PublishesOneSnapshot(t *testing.T) {
+	defer Capture().Restore()
+	resetWithBuilder(t, &mockBuilder{}, config.DefaultConfig(), nil)
+
+	reg := newMockRegistry("pair")
+	res := &mockResolver{id: "pair"}
+	before := st.Load()
+	SetResolverAndRegistry(res, reg)
+	after := st.Load()
+
+	if after == before || after.reg != reg || after.res != res {
+		t.Fatal("registry and resolver were not installed together")
+	}
+	if !IsRegistryPinned() || !IsResolverPinned() {
+		t.Fatal("both layers should be pinned")
+	}
+
+	SetResolverAndRegistry(nil, reg)
+	if st.Load() != after {
+		t.Fatal("nil resolver must leave the state unchanged")
+	}
+}
+
+func TestEntitySet_Dedupes(t *testing.T) {
+	defer Capture().Restore()
+	resetWithBuilder(t, builder.New(), config.DefaultConfig(), nil)
+
+	got := EntitySet(nilOrder{}, nilOrder{}, &nilOrder{}, unregisteredType{}, nil, struct{}{})
+	want := map[string]struct{}{
+		"nil.order":            {},
+		"rfx.unregisteredType": {},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("EntitySet() = %v, want %v", got, want)
+	}
+	if got := EntitySet(); got == nil || len(got) != 0 {
+		t.Fatalf("EntitySet() with no values = %v, want empty non-nil", got)
+	}
+}
+
+type (
+	bootUser    struct{}
+	bootOrder   struct{}
+	bootInvoice struct{}
+)
+
+func TestRegisterAll(t *testing.T) {
+	defer Capture().Restore()
+	resetWithBuilder(t, builder.New(), config.DefaultConfig(), nil)
+	Registry().Reset()
+
+	if err := RegisterAll("sales", bootUser{}, &bootOrder{}, []bootInvoice{}); err != nil {
+		t.Fatalf("RegisterAll: %v", err)
+	}
+	for v, want := range map[any]string{
+		bootUser{}:    "sales.bootUser",
+		bootOrder{}:   "sales.bootOrder",
+		bootInvoice{}: "sales.bootInvoice",
+	} {
+		if got := Entity(v); got != want {
+			t.Errorf("Entity(%T) = %q, want %q", v, got, want)
+		}
+	}
+
+	// Re-registering under a different prefix conflicts; other values still register.
+	err := RegisterAll("billing", bootInvoice{}, derivedA{})
+	if !errors.Is(err, registry.ErrConflictingRegistration) {
+		t.Fatalf("RegisterAll conflict: want ErrConflictingRegistration, got %v", err)
+	}
+	if got := Entity(derivedA{}); got != "billing.derivedA" {
+		t.Fatalf("Entity(derivedA) = %q, want billing.derivedA", got)
+	}
+}
+
+func TestEntityFast(t *testing.T) {
+	defer Capture().Restore()
+	resetWithBuilder(t, builder.New(), config.DefaultConfig(), nil)
+	Registry().Reset()
+	if err := RegisterType(reflect.TypeOf(unregisteredType{}), "registered.only"); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := EntityFast(resolveNamed{}); got != "resolve.named" {
+		t.Fatalf("EntityFast(Namer) = %q, want resolve.named", got)
+	}
+	// Registry and reflect are skipped for non-Namers.
+	if got := EntityFast(unregisteredType{}); got != "" {
+		t.Fatalf("EntityFast(registered non-Namer) = %q, want empty", got)
+	}
+	if got := EntityFast(nil); got != "" {
+		t.Fatalf("EntityFast(nil) = %q, want empty", got)
+	}
+}
+
+func TestGlobalPrefix(t *testing.T) {
+	defer Capture().Restore()
+	resetWithBuilder(t, builder.New(), config.DefaultConfig(), nil)
+	Registry().Reset()
+	if err := RegisterType(reflect.TypeOf(unregisteredType{}), "registered.only"); err != nil {
+		t.Fatal(err)
+	}
+
+	SetConfig(config.NewConfig(config.WithGlobalPrefix("tenant-a")))
+	for _, tc := range []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"namer", Entity(resolveNamed{}), "tenant-a/resolve.named"},
+		{"registry", Entity(unregisteredType{}), "tenant-a/registered.only"},
+		{"registry type", EntityType(reflect.TypeOf(unregisteredType{})), "tenant-a/registered.only"},
+		{"reflect", Entity(derivedA{}), "tenant-a/rfx.derivedA"},
+		{"exact", EntityTypeExact(reflect.TypeOf(derivedA{})), "tenant-a/rfx.derivedA"},
+		{"empty stays empty", Entity(struct{}{}), ""},
+	} {
+		if tc.got != tc.want {
+			t.Errorf("%s: got %q, want %q", tc.name, tc.got, tc.want)
+		}
+	}
+	// The prefix decorator keeps the strategies visible to introspection.
+	if got := ResolverStrategyNames(); !reflect.DeepEqual(got, []string{"namer", "registry", "reflect"}) {
+		t.Errorf("ResolverStrategyNames() = %v, want [namer registry reflect]", got)
+	}
+	if got, want := Explain(derivedA{}), `rfx.derivedA -> named rfx.derivedA -> namer miss -> registry miss -> reflect: "tenant-a/rfx.derivedA"`; got != want {
+		t.Errorf("Explain:\n got %s\nwant %s", got, want)
+	}
+
+	SetConfig(config.DefaultConfig())
+	if got := Entity(derivedA{}); got != "rfx.derivedA" {
+		t.Fatalf("after clearing GlobalPrefix: got %q, want rfx.derivedA", got)
+	}
+}
+
+func TestEntityWithResolver(t *testing.T) {
+	defer Capture().Restore()
+	cfg := apis.Config{IncludeBuiltins: true, MapPreferElem: false, MaxUnwrap: 3}
+	resetWithBuilder(t, &mockBuilder{}, cfg, nil)
+	before := st.Load()
+
+	res := &mockResolver{id: "injected"}
+	if got := EntityWithResolver(res, 1); got != "injected:T:F:3" {
+		t.Fatalf("EntityWithResolver = %q, want injected:T:F:3", got)
+	}
+	typ := reflect.TypeOf(unregisteredType{})
+	want := "injected:T:F:3:rfx.unregisteredType"
+	if got := EntityTypeWithResolver(res, typ); got != want {
+		t.Fatalf("EntityTypeWithResolver = %q, want %q", got, want)
+	}
+	if got := EntityWithResolver(res, typ); got != want {
+		t.Fatalf("EntityWithResolver(reflect.Type) = %q, want %q", got, want)
+	}
+	if st.Load() != before {
+		t.Fatalf("EntityWithResolver must not publish a snapshot")
+	}
+
+	// A nil resolver falls back to the global one.
+	if got, want := EntityWithResolver(nil, 1), Entity(1); got != want {
+		t.Fatalf("EntityWithResolver(nil) = %q, want %q", got, want)
+	}
+}
+
+func TestEntityNamer(t *testing.T) {
+	defer Capture().Restore()
+	resetWithBuilder(t, builder.New(), config.DefaultConfig(), nil)
+	Registry().Reset()
+	if err := RegisterType(reflect.TypeOf(resolveNamed{}), "registered.named"); err != nil {
+		t.Fatal(err)
+	}
+
+	// The registry is bypassed: only EntityName() is consulted.
+	if got := EntityNamer(resolveNamed{}); got != "resolve.named" {
+		t.Fatalf("EntityNamer(resolveNamed) = %q, want resolve.named", got)
+	}
+
+	// EntityNamer(unregisteredType{}) does not compile; mirror the
+	// constraint check with reflection so a regression is caught here too.
+	namer := reflect.TypeOf((*apis.Namer)(nil)).Elem()
+	if reflect.TypeOf(unregisteredType{}).Implements(namer) {
+		t.Fatal("unregisteredType unexpectedly satisfies apis.Namer")
+	}
+}
+
+type lazyEntity struct{}
+
+func TestRegisterLazy_SurvivesSetConfig(t *testing.T) {
+	defer Capture().Restore()
+	resetWithBuilder(t, builder.New(), config.DefaultConfig(), nil)
+	Registry().Reset()
+
+	err := Registry().(apis.LazyRegistry).RegisterLazy(reflect.TypeOf(lazyEntity{}), func() (string, error) {
+		return "lazy.entity", nil
+	})
+	if err != nil {
+		t.Fatalf("RegisterLazy: %v", err)
+	}
+
+	// The rebuild must carry the unresolved thunk over.
+	SetConfig(config.NewConfig(config.WithIncludeBuiltins(true)))
+	if got := Entity(lazyEntity{}); got != "lazy.entity" {
+		t.Fatalf("Entity after SetConfig = %q, want lazy.entity", got)
+	}
+}
+
+func TestCompareAndSetConfig(t *testing.T) {
+	defer Capture().Restore()
+	resetWithBuilder(t, builder.New(), config.DefaultConfig(), nil)
+
+	cur := Config()
+	next := cur
+	next.MaxUnwrap = 3
+
+	stale := cur
+	stale.MaxUnwrap = 5
+	if CompareAndSetConfig(stale, next) {
+		t.Fatal("CompareAndSetConfig with stale expected: want false")
+	}
+	if Config().MaxUnwrap != cur.MaxUnwrap {
+		t.Fatalf("config changed on failed CAS: MaxUnwrap = %d", Config().MaxUnwrap)
+	}
+
+	if !CompareAndSetConfig(cur, next) {
+		t.Fatal("CompareAndSetConfig with current expected: want true")
+	}
+	if Config().MaxUnwrap != 3 {
+		t.Fatalf("MaxUnwrap = %d, want 3", Config().MaxUnwrap)
+	}
+	// The previous value no longer matches.
+	if CompareAndSetConfig(cur, next) {
+		t.Fatal("second CompareAndSetConfig with old expected: want false")
+	}
+}
+
+type (
+	mapUserID  string
+	mapSession struct{}
+)
+
+func TestEntityMapParts(t *testing.T) {
+	defer Capture().Restore()
+	resetWithBuilder(t, builder.New(), config.DefaultConfig(), nil)
+	Registry().Reset()
+	_ = RegisterType(reflect.TypeOf(mapSession{}), "auth.session")
+
+	key, val, ok := EntityMapParts(map[mapUserID]mapSession{})
+	if !ok || key != "rfx.mapUserID" || val != "auth.session" {
+		t.Fatalf("EntityMapParts = (%q,%q,%v), want (rfx.mapUserID,auth.session,true)", key, val, ok)
+	}
+	key, val, ok = EntityMapPartsType(reflect.TypeOf(map[mapUserID][]*mapSession{}))
+	if !ok || key != "rfx.mapUserID" || val != "auth.session" {
+		t.Fatalf("EntityMapPartsType = (%q,%q,%v), want (rfx.mapUserID,auth.session,true)", key, val, ok)
+	}
+
+	for _, v := range []any{mapSession{}, []mapSession{}, nil} {
+		if key, val, ok := EntityMapParts(v); ok || key != "" || val != "" {
+			t.Errorf("EntityMapParts(%T) = (%q,%q,%v), want ('','',false)", v, key, val, ok)
+		}
+	}
+}
+
+// nilBuilder is a misbehaving builder that returns a nil registry or resolver.
+type nilBuilder struct{ nilReg bool }
+
+func (b nilBuilder) BuildRegistry(cfg apis.Config, prev apis.Registry, ext any) apis.Registry {
+	if b.nilReg {
+		return nil
+	}
+	return builder.New().BuildRegistry(cfg, prev, ext)
+}
+
+func (nilBuilder) BuildResolver(apis.Config, apis.Registry, apis.Resolver, any) apis.Resolver {
+	return nil
+}
+
+func TestTrySetConfig_ReturnsErrorAndKeepsSnapshot(t *testing.T) {
+	defer Capture().Restore()
+	resetWithBuilder(t, builder.New(), config.DefaultConfig(), nil)
+	// Install the misbehaving builder without rebuilding through it.
+	cur := st.Load()
+	st.Store(&state{cfg: cur.cfg, ext: cur.ext, reg: cur.reg, res: cur.res, bld: nilBuilder{nilReg: true}})
+	before := st.Load()
+
+	next := config.DefaultConfig()
+	next.MaxUnwrap = 2
+	if err := TrySetConfig(next); !errors.Is(err, ErrNilRegistry) {
+		t.Fatalf("TrySetConfig: want ErrNilRegistry, got %v", err)
+	}
+	if st.Load() != before {
+		t.Fatal("snapshot replaced on failure")
+	}
+
+	func() {
+		defer func() {
+			if r := recover(); r != ErrNilRegistry {
+				t.Fatalf("SetConfig: want panic ErrNilRegistry, got %v", r)
+			}
+		}()
+		SetConfig(next)
+	}()
+}
+
+func TestTrySetAll_ReturnsErrorAndKeepsSnapshot(t *testing.T) {
+	defer Capture().Restore()
+	resetWithBuilder(t, builder.New(), config.DefaultConfig(), nil)
+	before := st.Load()
+
+	if err := TrySetAll(nil, nil, nil, nil, nilBuilder{}); !errors.Is(err, ErrNilResolver) {
+		t.Fatalf("TrySetAll: want ErrNilResolver, got %v", err)
+	}
+	if st.Load() != before {
+		t.Fatal("snapshot replaced on failure")
+	}
+
+	cfg := config.DefaultConfig()
+	if err := TrySetAll(&cfg, nil, nil, nil, builder.New()); err != nil {
+		t.Fatalf("TrySetAll: %v", err)
+	}
+}
+
+// ifaceShape is a named interface; ifaceCircle implements it.
+type (
+	ifaceShape  interface{ Area() float64 }
+	ifaceCircle struct{}
+)
+
+func (ifaceCircle) Area() float64 { return 0 }
+
+func TestEntity_InterfaceValueAndType(t *testing.T) {
+	defer Capture().Restore()
+	resetWithBuilder(t, builder.New(), config.DefaultConfig(), nil)
+	Registry().Reset()
+
+	// A value stored in an interface resolves by its dynamic type.
+	var s ifaceShape = ifaceCircle{}
+	if got := Entity(s); got != "rfx.ifaceCircle" {
+		t.Fatalf("Entity(interface value) = %q, want rfx.ifaceCircle", got)
+	}
+	// The interface type itself resolves to its own name.
+	it := reflect.TypeOf((*ifaceShape)(nil)).Elem()
+	if got := EntityType(it); got != "rfx.ifaceShape" {
+		t.Fatalf("EntityType(interface type) = %q, want rfx.ifaceShape", got)
+	}
+	if got := Entity(it); got != "rfx.ifaceShape" {
+		t.Fatalf("Entity(interface reflect.Type) = %q, want rfx.ifaceShape", got)
+	}
+	// Registered interface types use the registered name.
+	_ = RegisterType(it, "geo.shape")
+	if got := EntityType(it); got != "geo.shape" {
+		t.Fatalf("EntityType(registered interface) = %q, want geo.shape", got)
+	}
+}
+
+func TestEntityTyped(t *testing.T) {
+	defer Capture().Restore()
+	resetWithBuilder(t, builder.New(), config.DefaultConfig(), nil)
+
+	got := EntityTyped(resolveNamed{})
+	if got != EntityName("resolve.named") || got.String() != Entity(resolveNamed{}) {
+		t.Fatalf("EntityTyped = %q, want resolve.named", got)
+	}
+}
@@ -0,0 +1,111 @@
+/*
+   Copyright 2025 The DIRPX Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rfx
+
+import (
+	"bytes"
+	"runtime"
+	"strconv"
+	"sync"
+	"sync/atomic"
+
+	"dirpx.dev/rfx/apis"
+)
+
+// WithConfigScope runs fn with cfg overriding the global rfx configuration
+// for Entity and EntityType calls made on the calling goroutine.
+//
+// Scopes nest: an inner scope overrides an outer one until it returns, after
+// which the outer scope applies again. Other goroutines, including ones
+// started by fn, keep using the global snapshot's configuration. The registry
+// and resolver are still taken from the global snapshot; only cfg changes.
+// The scope is popped even if fn panics.
+//
+// Go has no goroutine-local storage, so scopes are keyed by goroutine id.
+// While any scope is active anywhere in the process, every resolution through
+// the global state pays for reading that id from runtime.Stack (on the order
+// of a microsecond); otherwise the overhead is a single atomic load. Keep
+// scopes short-lived, e.g. for a migration step or a test.
+//
+// After Freeze, fn runs with the global configuration (or WithConfigScope
+// panics with ErrFrozen under FreezePanics).
+func WithConfigScope(cfg apis.Config, fn func()) {
+	if frozenGuard() {
+		fn()
+		return
+	}
+	id := goid()
+	pushScope(id, cfg)
+	defer popScope(id)
+	fn()
+}
+
+var (
+	// activeScopes counts scopes active on any goroutine.
+	activeScopes atomic.Int64
+	// scopes maps goroutine ids to their config stacks.
+	scopes sync.Map // map[uint64]*[]apis.Config
+)
+
+// pushScope pushes cfg onto the stack of goroutine id.
+func pushScope(id uint64, cfg apis.Config) {
+	v, _ := scopes.LoadOrStore(id, new([]apis.Config))
+	stack := v.(*[]apis.Config)
+	*stack = append(*stack, cfg)
+	activeScopes.Add(1)
+}
+
+// popScope pops the innermost scope of goroutine id.
+func popScope(id uint64) {
+	v, _ := scopes.Load(id)
+	stack := v.(*[]apis.Config)
+	*stack = (*stack)[:len(*stack)-1]
+	if len(*stack) == 0 {
+		scopes.Delete(id)
+	}
+	activeScopes.Add(-1)
+}
+
+// load returns the global snapshot, with cfg replaced by the innermost
+// config scope of the calling goroutine, if any.
+func load() *state {
+	s := st.Load()
+	if activeScopes.Load() == 0 {
+		return s
+	}
+	v, ok := scopes.Load(goid())
+	if !ok {
+		return s
+	}
+	stack := *v.(*[]apis.Config)
+	scoped := *s
+	scoped.cfg = stack[len(stack)-1]
+	return &scoped
+}
+
+// goid returns the id of the calling goroutine, parsed from its stack header
+// ("goroutine 123 [running]:").
+func goid() uint64 {
+	var buf [64]byte
+	b := buf[:runtime.Stack(buf[:], false)]
+	b = bytes.TrimPrefix(b, []byte("goroutine "))
+	if i := bytes.IndexByte(b, ' '); i >= 0 {
+		b = b[:i]
+	}
+	id, _ := strconv.ParseUint(string(b), 10, 64)
+	return id
+}
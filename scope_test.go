@@ -0,0 +1,86 @@
+package rfx
+
+import (
+	"reflect"
+	"testing"
+
+	"dirpx.dev/rfx/builder"
+	"dirpx.dev/rfx/config"
+)
+
+type scopeType struct{}
+
+func TestWithConfigScope_NestedAndGoroutineLocal(t *testing.T) {
+	defer Capture().Restore()
+	resetWithBuilder(t, builder.New(), config.DefaultConfig(), nil)
+
+	v := []*scopeType{}
+	const plain, marked = "rfx.scopeType", "[]*rfx.scopeType"
+	markers := config.NewConfig(config.WithKeepContainerMarkers(true))
+
+	WithConfigScope(markers, func() {
+		if got := Entity(v); got != marked {
+			t.Fatalf("outer scope: got %q, want %q", got, marked)
+		}
+		if got := EntityType(reflect.TypeOf(v)); got != marked {
+			t.Fatalf("outer scope (type): got %q, want %q", got, marked)
+		}
+		if got, _ := EntityNilSafe(v); got != marked {
+			t.Fatalf("outer scope (nil-safe): got %q, want %q", got, marked)
+		}
+
+		WithConfigScope(config.DefaultConfig(), func() {
+			if got := Entity(v); got != plain {
+				t.Fatalf("inner scope: got %q, want %q", got, plain)
+			}
+		})
+
+		if got := Entity(v); got != marked {
+			t.Fatalf("outer scope after inner: got %q, want %q", got, marked)
+		}
+
+		other := make(chan string)
+		go func() { other <- Entity(v) }()
+		if got := <-other; got != plain {
+			t.Fatalf("other goroutine: got %q, want %q", got, plain)
+		}
+	})
+
+	if got := Entity(v); got != plain {
+		t.Fatalf("after scope: got %q, want %q", got, plain)
+	}
+	if n := activeScopes.Load(); n != 0 {
+		t.Fatalf("activeScopes = %d, want 0", n)
+	}
+}
+
+func TestWithConfigScope_PopsOnPanic(t *testing.T) {
+	func() {
+		defer func() { _ = recover() }()
+		WithConfigScope(config.DefaultConfig(), func() { panic("boom") })
+	}()
+	if n := activeScopes.Load(); n != 0 {
+		t.Fatalf("activeScopes = %d, want 0", n)
+	}
+	if _, ok := scopes.Load(goid()); ok {
+		t.Fatal("scope stack leaked after panic")
+	}
+}
+
+func TestWithConfigScope_Frozen(t *testing.T) {
+	defer Capture().Restore()
+	defer unfreeze()
+	resetWithBuilder(t, builder.New(), config.DefaultConfig(), nil)
+
+	Freeze()
+	ran := false
+	WithConfigScope(config.NewConfig(config.WithKeepContainerMarkers(true)), func() {
+		ran = true
+		if got := Entity([]*scopeType{}); got != "rfx.scopeType" {
+			t.Fatalf("frozen scope: got %q, want the global config's name", got)
+		}
+	})
+	if !ran {
+		t.Fatal("fn did not run after Freeze")
+	}
+}
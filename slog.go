@@ -0,0 +1,39 @@
+/*
+   Copyright 2025 The DIRPX Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rfx
+
+import "log/slog"
+
+// LogValue returns a slog group describing v with the same keys and values
+// as Fields, in the order FieldEntity, FieldEntityID, FieldEntityVersion,
+// FieldEntityCategory. Empty values are omitted.
+func LogValue(v any) slog.Value {
+	f := Fields(v)
+	attrs := make([]slog.Attr, 0, len(f))
+	for _, k := range [...]string{FieldEntity, FieldEntityID, FieldEntityVersion, FieldEntityCategory} {
+		if val, ok := f[k]; ok {
+			attrs = append(attrs, slog.String(k, val))
+		}
+	}
+	return slog.GroupValue(attrs...)
+}
+
+// Attr returns a slog attribute with the given key and LogValue(v) as value,
+// e.g. slog.Info("saved", rfx.Attr("entity", user)).
+func Attr(key string, v any) slog.Attr {
+	return slog.Attr{Key: key, Value: LogValue(v)}
+}
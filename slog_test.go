@@ -0,0 +1,32 @@
+package rfx
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"reflect"
+	"testing"
+
+	"dirpx.dev/rfx/builder"
+	"dirpx.dev/rfx/config"
+)
+
+func TestAttr_Describer(t *testing.T) {
+	resetWithBuilder(t, builder.New(), config.DefaultConfig(), nil)
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+	logger.Info("saved", Attr("entity", fullEntity{}), Attr("key", namerOnly{}))
+
+	var rec map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &rec); err != nil {
+		t.Fatalf("unmarshal %q: %v", buf.String(), err)
+	}
+	wantEntity := map[string]any{FieldEntity: "cache.entry", FieldEntityID: "abc123", FieldEntityVersion: "v2"}
+	if got := rec["entity"]; !reflect.DeepEqual(got, wantEntity) {
+		t.Fatalf("entity = %v, want %v", got, wantEntity)
+	}
+	if got, want := rec["key"], map[string]any{FieldEntity: "cache.key"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("key = %v, want %v", got, want)
+	}
+}
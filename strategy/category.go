@@ -0,0 +1,71 @@
+/*
+   Copyright 2025 The DIRPX Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package strategy
+
+import (
+	"reflect"
+
+	"dirpx.dev/rfx/apis"
+)
+
+// NewCategoryPrefixStrategy creates an apis.Strategy that prefixes the name of
+// apis.Describer values with the prefix mapped to their EntityCategory, e.g.
+// {"payment": "pay."} turns "refund" into "pay.refund". The prefix is used
+// verbatim, so include any separator in it. The map is copied.
+// Values that are not Describers, or whose category has no prefix, fall
+// through; place it before NewNamerStrategy so it sees Describers first.
+func NewCategoryPrefixStrategy(prefixes map[string]string) apis.Strategy {
+	cp := make(map[string]string, len(prefixes))
+	for cat, p := range prefixes {
+		cp[cat] = p
+	}
+	return &categoryPrefixStrategy{prefixes: cp}
+}
+
+// categoryPrefixStrategy returns prefixes[EntityCategory()] + EntityName()
+// for Describer values whose category is mapped.
+type categoryPrefixStrategy struct {
+	// prefixes maps a category to the prefix for its names.
+	prefixes map[string]string
+}
+
+// Ensure categoryPrefixStrategy implements apis.Strategy.
+var _ apis.Strategy = (*categoryPrefixStrategy)(nil)
+
+// Name returns "category-prefix".
+func (*categoryPrefixStrategy) Name() string { return "category-prefix" }
+
+// TryResolve handles Describer values whose category has a prefix.
+func (s *categoryPrefixStrategy) TryResolve(v any, _ apis.Config) (string, bool) {
+	d, ok := v.(apis.Describer)
+	if !ok {
+		return "", false
+	}
+	p, ok := s.prefixes[d.EntityCategory()]
+	if !ok {
+		return "", false
+	}
+	return p + d.EntityName(), true
+}
+
+// TypeResolvable returns false: Describer requires an instance.
+func (*categoryPrefixStrategy) TypeResolvable() bool { return false }
+
+// TryResolveType always returns false: Describer requires an instance.
+func (*categoryPrefixStrategy) TryResolveType(_ reflect.Type, _ apis.Config) (string, bool) {
+	return "", false
+}
@@ -0,0 +1,71 @@
+/*
+   Copyright 2025 The DIRPX Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package strategy_test
+
+import (
+	"reflect"
+	"testing"
+
+	"dirpx.dev/rfx/apis"
+	"dirpx.dev/rfx/resolver"
+	"dirpx.dev/rfx/strategy"
+)
+
+// paymentType is a Describer in the "payment" category.
+type paymentType struct{}
+
+func (paymentType) EntityName() string        { return "refund" }
+func (paymentType) EntityVersion() string     { return "" }
+func (paymentType) EntityCategory() string    { return "payment" }
+func (paymentType) EntityDescription() string { return "" }
+
+func TestCategoryPrefixStrategy_TryResolve(t *testing.T) {
+	prefixes := map[string]string{"payment": "pay."}
+	s := strategy.NewCategoryPrefixStrategy(prefixes)
+	prefixes["payment"] = "mutated." // the strategy keeps its own copy
+	conf := apis.Config{}
+
+	if got, ok := s.TryResolve(paymentType{}, conf); !ok || got != "pay.refund" {
+		t.Fatalf("TryResolve(payment): got (%q,%v), want (pay.refund,true)", got, ok)
+	}
+	// Unmapped category falls through.
+	if got, ok := s.TryResolve(describedType{}, conf); ok || got != "" {
+		t.Fatalf("TryResolve(identity): got (%q,%v), want ('',false)", got, ok)
+	}
+	// Plain Namer falls through.
+	if got, ok := s.TryResolve(namedType{}, conf); ok || got != "" {
+		t.Fatalf("TryResolve(namer): got (%q,%v), want ('',false)", got, ok)
+	}
+	if got, ok := s.TryResolveType(reflect.TypeOf(paymentType{}), conf); ok || got != "" {
+		t.Fatalf("TryResolveType: got (%q,%v), want ('',false)", got, ok)
+	}
+}
+
+func TestCategoryPrefixStrategy_BeforeNamer(t *testing.T) {
+	res := resolver.New(
+		strategy.NewCategoryPrefixStrategy(map[string]string{"payment": "pay."}),
+		strategy.NewNamerStrategy(),
+	)
+	conf := apis.Config{}
+
+	if got := res.Resolve(paymentType{}, conf); got != "pay.refund" {
+		t.Fatalf("payment: got %q, want %q", got, "pay.refund")
+	}
+	if got := res.Resolve(describedType{}, conf); got != "domain.user" {
+		t.Fatalf("identity: got %q, want %q", got, "domain.user")
+	}
+}
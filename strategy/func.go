@@ -0,0 +1,135 @@
+/*
+   Copyright 2025 The DIRPX Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package strategy
+
+import (
+	"path"
+	"reflect"
+	"runtime"
+	"strconv"
+	"strings"
+
+	"dirpx.dev/rfx/apis"
+	uref "dirpx.dev/rfx/utils/reflect"
+)
+
+// NewFuncStrategy creates an apis.Strategy that names func values after the
+// function they point to, using runtime.FuncForPC:
+//
+//   - free functions resolve to "pkg.Func";
+//   - method values such as user.Save and method expressions such as
+//     (*User).Save resolve to "pkg.User.Save", whatever the receiver kind;
+//   - closures have no stable name of their own and resolve to
+//     "closure@file.go:line", the position of the func literal.
+//
+// Non-func and nil func values fall through. Func types carry no name, so
+// place it before the reflect strategy, which would otherwise yield "".
+func NewFuncStrategy() apis.Strategy {
+	return funcStrategy{}
+}
+
+// funcStrategy names func values via runtime symbol information.
+type funcStrategy struct{}
+
+// Ensure funcStrategy implements apis.Strategy.
+var _ apis.Strategy = funcStrategy{}
+
+// Name returns "func".
+func (funcStrategy) Name() string { return "func" }
+
+// TryResolve names v if it is a non-nil func.
+func (funcStrategy) TryResolve(v any, cfg apis.Config) (string, bool) {
+	if v == nil {
+		return "", false
+	}
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Func || rv.IsNil() {
+		return "", false
+	}
+	pc := rv.Pointer()
+	fn := runtime.FuncForPC(pc)
+	if fn == nil {
+		return "", false
+	}
+	return funcName(fn, pc, cfg), true
+}
+
+// TypeResolvable returns false: the function is only known from an instance.
+func (funcStrategy) TypeResolvable() bool { return false }
+
+// TryResolveType always returns false: the function is only known from an instance.
+func (funcStrategy) TryResolveType(_ reflect.Type, _ apis.Config) (string, bool) {
+	return "", false
+}
+
+// funcName turns a runtime symbol such as "example.com/m/pkg.(*User).Save-fm"
+// into "pkg.User.Save", or into a file:line name for closures.
+func funcName(fn *runtime.Func, pc uintptr, cfg apis.Config) string {
+	full := fn.Name()
+
+	// The package path ends at the first '.' after the last '/'.
+	slash := strings.LastIndexByte(full, '/')
+	dot := strings.IndexByte(full[slash+1:], '.')
+	if dot < 0 {
+		return full
+	}
+	pkgPath, sym := full[:slash+1+dot], full[slash+1+dot+1:]
+
+	if isClosure(sym) {
+		file, line := fn.FileLine(pc)
+		return "closure@" + path.Base(file) + ":" + strconv.Itoa(line)
+	}
+
+	sym = strings.TrimSuffix(sym, "-fm") // method value wrapper
+	sym = strings.NewReplacer("(*", "", "(", "", ")", "").Replace(sym)
+	sym = stripTypeArgs(sym)
+	return uref.PackageBase(pkgPath, cfg.TrimModuleVersion) + "." + sym
+}
+
+// stripTypeArgs removes every bracketed type-argument segment from sym, so
+// "ZG[...].Save" becomes "ZG.Save" and "Map[...]" becomes "Map".
+func stripTypeArgs(sym string) string {
+	if strings.IndexByte(sym, '[') < 0 {
+		return sym
+	}
+	var b strings.Builder
+	depth := 0
+	for i := 0; i < len(sym); i++ {
+		switch c := sym[i]; {
+		case c == '[':
+			depth++
+		case c == ']' && depth > 0:
+			depth--
+		case depth == 0:
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
+
+// isClosure reports whether a symbol names a func literal: the compiler calls
+// them "outer.func1", "outer.func1.2" or "glob..func1" at package level.
+func isClosure(sym string) bool {
+	for _, part := range strings.Split(sym, ".") {
+		if len(part) > len("func") && strings.HasPrefix(part, "func") {
+			if _, err := strconv.Atoi(part[len("func"):]); err == nil {
+				return true
+			}
+		}
+	}
+	return strings.HasPrefix(sym, "glob.")
+}
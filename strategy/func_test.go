@@ -0,0 +1,88 @@
+/*
+   Copyright 2025 The DIRPX Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package strategy_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"dirpx.dev/rfx/strategy"
+)
+
+type funcUser struct{}
+
+func (funcUser) Save()   {}
+func (*funcUser) Touch() {}
+
+func funcFree() {}
+
+type funcGen[T any] struct{ V T }
+
+func (funcGen[T]) Load()  {}
+func (*funcGen[T]) Save() {}
+
+func funcGeneric[T any]() {}
+
+func TestFuncStrategy_Names(t *testing.T) {
+	s := strategy.NewFuncStrategy()
+	u := funcUser{}
+
+	cases := []struct {
+		name string
+		v    any
+		want string
+	}{
+		{"free function", funcFree, "strategy_test.funcFree"},
+		{"method value", u.Save, "strategy_test.funcUser.Save"},
+		{"pointer method value", (&u).Touch, "strategy_test.funcUser.Touch"},
+		{"method expression", funcUser.Save, "strategy_test.funcUser.Save"},
+		{"generic receiver method", funcGen[int].Load, "strategy_test.funcGen.Load"},
+		{"generic pointer method value", (&funcGen[int]{}).Save, "strategy_test.funcGen.Save"},
+		{"generic function", funcGeneric[string], "strategy_test.funcGeneric"},
+	}
+	for _, tc := range cases {
+		got, ok := s.TryResolve(tc.v, cfg())
+		if !ok || got != tc.want {
+			t.Errorf("%s: got (%q,%v), want (%q,true)", tc.name, got, ok, tc.want)
+		}
+	}
+}
+
+func TestFuncStrategy_Closure(t *testing.T) {
+	s := strategy.NewFuncStrategy()
+	closure := func() {}
+
+	got, ok := s.TryResolve(closure, cfg())
+	if !ok || !strings.HasPrefix(got, "closure@func_test.go:") {
+		t.Fatalf("closure: got (%q,%v), want closure@func_test.go:<line>", got, ok)
+	}
+}
+
+func TestFuncStrategy_FallsThrough(t *testing.T) {
+	s := strategy.NewFuncStrategy()
+	var nilFunc func()
+
+	for _, v := range []any{nil, nilFunc, A{}} {
+		if got, ok := s.TryResolve(v, cfg()); ok || got != "" {
+			t.Errorf("TryResolve(%T): got (%q,%v), want ('',false)", v, got, ok)
+		}
+	}
+	if _, ok := s.TryResolveType(reflect.TypeOf(funcFree), cfg()); ok {
+		t.Error("TryResolveType must not handle func types")
+	}
+}
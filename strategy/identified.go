@@ -0,0 +1,69 @@
+/*
+   Copyright 2025 The DIRPX Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package strategy
+
+import (
+	"reflect"
+
+	"dirpx.dev/rfx/apis"
+)
+
+// NewIdentifiedStrategy creates an apis.Strategy that qualifies the name of
+// values implementing both apis.Namer and apis.Identifier with their
+// per-instance ID, e.g. "domain.user:123" for sep ":". An empty EntityID
+// yields the plain EntityName. Other values fall through, so place it before
+// NewNamerStrategy in a custom builder, typically one used for audit logs.
+func NewIdentifiedStrategy(sep string) apis.Strategy {
+	return &identifiedStrategy{sep: sep}
+}
+
+// identifiedStrategy returns EntityName() + sep + EntityID() for
+// Namer+Identifier values.
+type identifiedStrategy struct {
+	// sep separates the name from the ID.
+	sep string
+}
+
+// Ensure identifiedStrategy implements apis.Strategy.
+var _ apis.Strategy = (*identifiedStrategy)(nil)
+
+// Name returns "identified".
+func (*identifiedStrategy) Name() string { return "identified" }
+
+// TryResolve handles values implementing both Namer and Identifier.
+func (s *identifiedStrategy) TryResolve(v any, _ apis.Config) (string, bool) {
+	n, ok := v.(apis.Namer)
+	if !ok {
+		return "", false
+	}
+	i, ok := v.(apis.Identifier)
+	if !ok {
+		return "", false
+	}
+	if id := i.EntityID(); id != "" {
+		return n.EntityName() + s.sep + id, true
+	}
+	return n.EntityName(), true
+}
+
+// TypeResolvable returns false: Identifier requires an instance.
+func (*identifiedStrategy) TypeResolvable() bool { return false }
+
+// TryResolveType always returns false: Identifier requires an instance.
+func (*identifiedStrategy) TryResolveType(_ reflect.Type, _ apis.Config) (string, bool) {
+	return "", false
+}
@@ -0,0 +1,67 @@
+/*
+   Copyright 2025 The DIRPX Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package strategy_test
+
+import (
+	"reflect"
+	"testing"
+
+	"dirpx.dev/rfx/apis"
+	"dirpx.dev/rfx/resolver"
+	"dirpx.dev/rfx/strategy"
+)
+
+// identifiedUser is a Namer carrying a per-instance ID.
+type identifiedUser struct{ id string }
+
+func (identifiedUser) EntityName() string { return "domain.user" }
+func (u identifiedUser) EntityID() string { return u.id }
+
+// bareID implements Identifier but not Namer.
+type bareID struct{}
+
+func (bareID) EntityID() string { return "1" }
+
+func TestIdentifiedStrategy_TryResolve(t *testing.T) {
+	s := strategy.NewIdentifiedStrategy(":")
+	conf := apis.Config{}
+
+	if got, ok := s.TryResolve(identifiedUser{id: "123"}, conf); !ok || got != "domain.user:123" {
+		t.Fatalf("TryResolve(id): got (%q,%v), want (domain.user:123,true)", got, ok)
+	}
+	if got, ok := s.TryResolve(identifiedUser{}, conf); !ok || got != "domain.user" {
+		t.Fatalf("TryResolve(no id): got (%q,%v), want (domain.user,true)", got, ok)
+	}
+	for _, v := range []any{namedType{}, bareID{}, nil} {
+		if got, ok := s.TryResolve(v, conf); ok || got != "" {
+			t.Fatalf("TryResolve(%T): got (%q,%v), want ('',false)", v, got, ok)
+		}
+	}
+	if got, ok := s.TryResolveType(reflect.TypeOf(identifiedUser{}), conf); ok || got != "" {
+		t.Fatalf("TryResolveType: got (%q,%v), want ('',false)", got, ok)
+	}
+}
+
+func TestIdentifiedStrategy_BeforeNamer(t *testing.T) {
+	res := resolver.New(
+		strategy.NewIdentifiedStrategy("#"),
+		strategy.NewNamerStrategy(),
+	)
+	if got := res.Resolve(identifiedUser{id: "7"}, apis.Config{}); got != "domain.user#7" {
+		t.Fatalf("identified: got %q, want %q", got, "domain.user#7")
+	}
+}
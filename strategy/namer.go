@@ -45,6 +45,12 @@ func (*namerStrategy) TryResolve(v any, _ apis.Config) (string, bool) {
 	return "", false
 }
 
+// Name returns "namer".
+func (*namerStrategy) Name() string { return "namer" }
+
+// TypeResolvable returns false: Namer requires an instance.
+func (*namerStrategy) TypeResolvable() bool { return false }
+
 // TryResolveType always returns false: Namer requires an instance.
 func (*namerStrategy) TryResolveType(_ reflect.Type, _ apis.Config) (string, bool) {
 	// No instance -> cannot use Namer.
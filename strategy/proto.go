@@ -0,0 +1,97 @@
+/*
+   Copyright 2025 The DIRPX Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package strategy
+
+import (
+	"reflect"
+	"sync"
+
+	"dirpx.dev/rfx/apis"
+)
+
+// NewProtoStrategy creates an apis.Strategy that names protobuf messages by
+// their descriptor's full name (e.g. "acme.user.v1.User").
+//
+// Messages are recognized by shape, v.ProtoReflect().Descriptor().FullName()
+// with a string-kinded result, so rfx does not depend on the protobuf module.
+// The lookup is done with reflection once per type and memoized. Non-proto
+// values and nil messages fall through. Since the descriptor is reached
+// through an instance, the strategy is not type-resolvable.
+func NewProtoStrategy() apis.Strategy {
+	return &protoStrategy{}
+}
+
+// protoStrategy resolves protobuf messages by descriptor full name.
+type protoStrategy struct {
+	// names memoizes full names per message type; "" marks non-proto types.
+	names sync.Map // map[reflect.Type]string
+}
+
+// Ensure protoStrategy implements apis.Strategy.
+var _ apis.Strategy = (*protoStrategy)(nil)
+
+// Name returns "proto".
+func (*protoStrategy) Name() string { return "proto" }
+
+// TypeResolvable returns false: the descriptor requires an instance.
+func (*protoStrategy) TypeResolvable() bool { return false }
+
+// TryResolve returns the message full name if v is a protobuf message.
+func (s *protoStrategy) TryResolve(v any, _ apis.Config) (string, bool) {
+	if v == nil {
+		return "", false
+	}
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Ptr && rv.IsNil() {
+		return "", false
+	}
+	t := rv.Type()
+	if cached, ok := s.names.Load(t); ok {
+		name := cached.(string)
+		return name, name != ""
+	}
+	name := protoFullName(rv)
+	s.names.Store(t, name)
+	return name, name != ""
+}
+
+// TryResolveType always returns false: the descriptor requires an instance.
+func (*protoStrategy) TryResolveType(_ reflect.Type, _ apis.Config) (string, bool) {
+	return "", false
+}
+
+// protoFullName evaluates v.ProtoReflect().Descriptor().FullName() by
+// reflection, returning "" if v does not have that shape.
+func protoFullName(v reflect.Value) string {
+	for _, method := range []string{"ProtoReflect", "Descriptor", "FullName"} {
+		m := v.MethodByName(method)
+		if !m.IsValid() || m.Type().NumIn() != 0 || m.Type().NumOut() != 1 {
+			return ""
+		}
+		v = m.Call(nil)[0]
+		switch v.Kind() {
+		case reflect.Interface, reflect.Ptr:
+			if v.IsNil() {
+				return ""
+			}
+		}
+	}
+	if v.Kind() != reflect.String {
+		return ""
+	}
+	return v.String()
+}
@@ -0,0 +1,60 @@
+/*
+   Copyright 2025 The DIRPX Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package strategy_test
+
+import (
+	"reflect"
+	"testing"
+
+	"dirpx.dev/rfx/strategy"
+)
+
+// The fake* types mimic the generated protobuf API shape:
+// msg.ProtoReflect().Descriptor().FullName().
+type (
+	fakeFullName   string
+	fakeDescriptor struct{ name string }
+	fakeReflect    struct{ d fakeDescriptor }
+	fakeProtoMsg   struct{ name string }
+)
+
+func (d fakeDescriptor) FullName() fakeFullName  { return fakeFullName(d.name) }
+func (r fakeReflect) Descriptor() fakeDescriptor { return r.d }
+func (m *fakeProtoMsg) ProtoReflect() fakeReflect {
+	return fakeReflect{d: fakeDescriptor{name: m.name}}
+}
+
+func TestProtoStrategy(t *testing.T) {
+	s := strategy.NewProtoStrategy()
+
+	if got, ok := s.TryResolve(&fakeProtoMsg{name: "acme.user.v1.User"}, cfg()); !ok || got != "acme.user.v1.User" {
+		t.Fatalf("proto message: got (%q,%v)", got, ok)
+	}
+	// Memoized per type.
+	if got, _ := s.TryResolve(&fakeProtoMsg{name: "other"}, cfg()); got != "acme.user.v1.User" {
+		t.Fatalf("memoized: got %q", got)
+	}
+
+	for _, v := range []any{A{}, "str", (*fakeProtoMsg)(nil), nil} {
+		if got, ok := s.TryResolve(v, cfg()); ok || got != "" {
+			t.Errorf("%T: got (%q,%v), want fall through", v, got, ok)
+		}
+	}
+	if _, ok := s.TryResolveType(reflect.TypeOf(&fakeProtoMsg{}), cfg()); ok {
+		t.Fatal("TryResolveType must fall through")
+	}
+}
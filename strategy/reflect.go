@@ -17,15 +17,26 @@
 package strategy
 
 import (
-	"path"
+	"fmt"
+	"hash/fnv"
+	"math/rand/v2"
 	"reflect"
+	"sort"
+	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
+	"unicode"
+	"unicode/utf8"
 
 	"dirpx.dev/rfx/apis"
 	uref "dirpx.dev/rfx/utils/reflect"
 )
 
+// UnsafeKindName is the name given to uintptr and unsafe.Pointer under
+// apis.UnsafeKindFixedName.
+const UnsafeKindName = "unsafe"
+
 // NewReflectStrategy creates an apis.Strategy that resolves names via reflection
 // using utils/reflect.Normalize and memoization.
 func NewReflectStrategy() apis.Strategy {
@@ -45,12 +56,31 @@ type cacheKey struct {
 	t              reflect.Type
 	includeBuiltin bool
 	maxUnwrap      int16
+	finalNamed     bool
 	mapPreferElem  bool
+	outermost      bool
+	unsafeKinds    apis.UnsafeKindPolicy
+	keepMarkers    bool
+	keepArrayLen   bool
+	distinguishArr bool
+	distinguishPtr bool
+	ptrDepth       bool
+	keepPtrDepth   bool
+	trimModVer     bool
+	omitPkg        bool
+	friendlyBytes  bool
+	pkgPathHash    bool
+	nameStyle      apis.NameStyle
+	maxNameLen     int
+	aliases        uint64
 }
 
 // typeNameCache caches resolved type names by (type, config knobs).
 var typeNameCache sync.Map // key: cacheKey, val: string
 
+// Name returns "reflect".
+func (reflectStrategy) Name() string { return "reflect" }
+
 // TryResolve computes the domain-oriented name for v's type.
 func (reflectStrategy) TryResolve(v any, cfg apis.Config) (string, bool) {
 	if v == nil {
@@ -73,34 +103,452 @@ func byType(t reflect.Type, cfg apis.Config) string {
 		t:              t,
 		includeBuiltin: cfg.IncludeBuiltins,
 		maxUnwrap:      int16(cfg.MaxUnwrap),
+		finalNamed:     cfg.UnwrapFinalNamed,
 		mapPreferElem:  cfg.MapPreferElem,
+		outermost:      cfg.NormalizeOutermost,
+		unsafeKinds:    cfg.UnsafeKindPolicy(),
+		keepMarkers:    cfg.KeepContainerMarkers,
+		keepArrayLen:   cfg.KeepArrayLen,
+		distinguishArr: cfg.DistinguishArrays,
+		distinguishPtr: cfg.DistinguishPointers,
+		ptrDepth:       cfg.PointerDepthInName,
+		keepPtrDepth:   cfg.KeepPointerDepth,
+		trimModVer:     cfg.TrimModuleVersion,
+		omitPkg:        cfg.OmitPackage,
+		friendlyBytes:  cfg.FriendlyByteSlices,
+		pkgPathHash:    cfg.PkgPathHashSuffix,
+		nameStyle:      cfg.NameStyle,
+		maxNameLen:     cfg.MaxNameLen,
+		aliases:        aliasesHash(cfg.TypeAliases),
 	}
 	if v, ok := typeNameCache.Load(key); ok {
+		// Sample hits so the hot path does not contend on a shared counter.
+		if rand.Uint32()%hitSampleRate == 0 {
+			cacheHits.Add(hitSampleRate)
+		}
 		return v.(string)
 	}
+	cacheMisses.Add(1)
+
+	name, _ := ReflectName(t, cfg)
+	cacheStore(key, name)
+	return name
+}
+
+// ReflectName returns the name the reflect strategy gives t under cfg,
+// computed from scratch: it neither reads nor fills the strategy's shared
+// cache, so tools resolving types in bulk can manage their own caching.
+// ok is false, and name "", when no name can be derived (e.g. t is nil or
+// unnamed, or a builtin with IncludeBuiltins unset). Types accepted by
+// IsExactType skip normalization entirely.
+func ReflectName(t reflect.Type, cfg apis.Config) (name string, ok bool) {
+	if t == nil {
+		return "", false
+	}
+	if IsExactType(t) {
+		name = assembleName(t, t, nil, cfg)
+		return name, name != ""
+	}
+
+	// Special-case byte and rune slices before the generic unwrap.
+	if cfg.FriendlyByteSlices {
+		if n, ok := friendlySliceName(t); ok {
+			return n, true
+		}
+	}
 
-	base, err := uref.Normalize(t, cfg)
+	var (
+		base   reflect.Type
+		layers []reflect.Type
+		err    error
+	)
+	if cfg.KeepContainerMarkers || cfg.KeepArrayLen || cfg.DistinguishPointers || cfg.KeepPointerDepth {
+		base, layers, err = uref.NormalizeDetailed(t, cfg)
+	} else {
+		base, err = uref.Normalize(t, cfg)
+	}
 	if err != nil || base == nil {
-		typeNameCache.Store(key, "")
-		return ""
+		return "", false
 	}
 
-	name := stripTypeParams(base.Name())
+	name = assembleName(t, base, layers, cfg)
+	return name, name != ""
+}
+
+// IsExactType reports whether Normalize returns t unchanged under any config:
+// t is named and its kind is neither a container (pointer, slice, array, map,
+// chan) nor an unsafe kind.
+func IsExactType(t reflect.Type) bool {
+	return t != nil && !uref.IsContainerKind(t.Kind()) && !uref.IsUnsafeKind(t.Kind()) && t.Name() != ""
+}
+
+// assembleName builds the name of the normalized base of t, given the
+// container layers peeled on the way (outermost first), under cfg.
+func assembleName(t, base reflect.Type, layers []reflect.Type, cfg apis.Config) string {
+	name := uref.StripTypeParams(base.Name())
 	if p := base.PkgPath(); p != "" {
-		name = path.Base(p) + "." + name
+		if !cfg.OmitPackage {
+			name = uref.PackageBase(p, cfg.TrimModuleVersion) + "." + name
+		}
 	} else if !cfg.IncludeBuiltins {
 		// Hide builtin/no-package names if requested.
 		name = ""
 	}
 
-	typeNameCache.Store(key, name)
+	// Apply the final alias remap: full "pkgpath.Type" first, then "pkg.Type".
+	aliased := false
+	if name != "" && len(cfg.TypeAliases) > 0 {
+		if alias, ok := cfg.TypeAliases[uref.FullName(base)]; ok {
+			name, aliased = alias, true
+		} else if alias, ok := cfg.TypeAliases[name]; ok {
+			name, aliased = alias, true
+		}
+	}
+
+	// Disambiguate equal package bases; aliases are chosen names and kept as is.
+	if cfg.PkgPathHashSuffix && !aliased && name != "" && base.PkgPath() != "" {
+		name += "." + pkgPathHash(base.PkgPath())
+	}
+
+	// Never emit empty segments ("pkg..Type", ".Type") from partial pieces.
+	name = collapseSeparators(name)
+
+	// Name unsafe kinds uniformly if requested, regardless of IncludeBuiltins.
+	if cfg.UnsafeKindPolicy() == apis.UnsafeKindFixedName && uref.IsUnsafeKind(base.Kind()) {
+		name = UnsafeKindName
+	}
+
+	switch {
+	case name == "":
+	case cfg.KeepContainerMarkers && len(layers) > 0:
+		name = withContainerMarkers(name, base, layers)
+	case cfg.DistinguishArrays && t.Kind() == reflect.Array:
+		// Read the root array length before unwrapping: [16]byte -> "[16]uint8".
+		name = "[" + strconv.Itoa(t.Len()) + "]" + name
+	case cfg.KeepArrayLen && len(layers) > 0 && layers[0].Kind() == reflect.Array:
+		name += "[" + strconv.Itoa(layers[0].Len()) + "]"
+	}
+	if name != "" && !cfg.KeepContainerMarkers {
+		if n := pointerMarkers(layers, cfg); n > 0 {
+			name = strings.Repeat("*", n) + name
+		}
+	}
+
+	// Restyle derived names last so the style covers markers and suffixes.
+	if !aliased {
+		name = styleName(name, cfg.NameStyle)
+	}
+
+	return truncateName(name, cfg.MaxNameLen)
+}
+
+// withContainerMarkers wraps name in the Go-like syntax of the unwrapped
+// container layers (outermost first), e.g. "pkg.User" with layers
+// [[]*User, *User] becomes "[]*pkg.User". For maps, the side that was not
+// followed is rendered with reflect's own type string.
+func withContainerMarkers(name string, base reflect.Type, layers []reflect.Type) string {
+	inner := base
+	for i := len(layers) - 1; i >= 0; i-- {
+		c := layers[i]
+		switch c.Kind() {
+		case reflect.Ptr:
+			name = "*" + name
+		case reflect.Slice:
+			name = "[]" + name
+		case reflect.Array:
+			name = "[" + strconv.Itoa(c.Len()) + "]" + name
+		case reflect.Chan:
+			switch c.ChanDir() {
+			case reflect.RecvDir:
+				name = "<-chan " + name
+			case reflect.SendDir:
+				name = "chan<- " + name
+			default:
+				name = "chan " + name
+			}
+		case reflect.Map:
+			if c.Elem() == inner {
+				name = "map[" + c.Key().String() + "]" + name
+			} else {
+				name = "map[" + name + "]" + c.Elem().String()
+			}
+		}
+		inner = c
+	}
 	return name
 }
 
-// stripTypeParams removes generic type instantiation suffix: "T[int,string]" -> "T".
-func stripTypeParams(s string) string {
-	if i := strings.IndexByte(s, '['); i >= 0 {
-		return s[:i]
+// pointerMarkers returns how many "*" markers to prefix for the unwrapped
+// layers under cfg. KeepPointerDepth counts every pointer layer peeled.
+// Otherwise, with DistinguishPointers, it is one for a pointer root, or one
+// per leading pointer level with PointerDepthInName; pointers nested inside
+// other containers do not count.
+func pointerMarkers(layers []reflect.Type, cfg apis.Config) int {
+	if cfg.KeepPointerDepth {
+		n := 0
+		for _, l := range layers {
+			if l.Kind() == reflect.Ptr {
+				n++
+			}
+		}
+		return n
+	}
+	if !cfg.DistinguishPointers {
+		return 0
+	}
+	lead := 0
+	for lead < len(layers) && layers[lead].Kind() == reflect.Ptr {
+		lead++
+	}
+	if lead > 0 && !cfg.PointerDepthInName {
+		return 1
+	}
+	return lead
+}
+
+// Names given to byte and rune slices under Config.FriendlyByteSlices.
+const (
+	BytesName = "bytes"
+	RunesName = "runes"
+)
+
+var (
+	byteType = reflect.TypeOf(byte(0))
+	runeType = reflect.TypeOf(rune(0))
+)
+
+// friendlySliceName returns BytesName for an unnamed []byte and RunesName for
+// an unnamed []rune.
+func friendlySliceName(t reflect.Type) (string, bool) {
+	if t.Kind() != reflect.Slice || t.Name() != "" {
+		return "", false
+	}
+	switch t.Elem() {
+	case byteType:
+		return BytesName, true
+	case runeType:
+		return RunesName, true
 	}
-	return s
+	return "", false
+}
+
+// pkgPathHash returns four hex digits of an FNV-1a hash of the full package path.
+func pkgPathHash(pkgPath string) string {
+	h := fnv.New32a()
+	h.Write([]byte(pkgPath))
+	return fmt.Sprintf("%04x", h.Sum32()&0xffff)
+}
+
+// nameSeparator separates the segments of a resolved name.
+const nameSeparator = "."
+
+// collapseSeparators collapses runs of nameSeparator into one and trims
+// leading and trailing separators, so empty segments never reach a name.
+func collapseSeparators(name string) string {
+	if !strings.Contains(name, nameSeparator+nameSeparator) &&
+		!strings.HasPrefix(name, nameSeparator) && !strings.HasSuffix(name, nameSeparator) {
+		return name
+	}
+	parts := strings.Split(name, nameSeparator)
+	kept := parts[:0]
+	for _, p := range parts {
+		if p != "" {
+			kept = append(kept, p)
+		}
+	}
+	return strings.Join(kept, nameSeparator)
+}
+
+// styleName formats name in style. Under the snake and kebab styles it
+// lowercases, inserts the separator at word boundaries ("UserID" ->
+// "user_id", "HTTPServer" -> "http_server") and replaces nameSeparator with
+// it; other characters, such as container markers, are kept.
+func styleName(name string, style apis.NameStyle) string {
+	var sep rune
+	switch style {
+	case apis.NameStyleSnakeCase:
+		sep = '_'
+	case apis.NameStyleKebabCase:
+		sep = '-'
+	default:
+		return name
+	}
+
+	rs := []rune(name)
+	var b strings.Builder
+	b.Grow(len(name) + 4)
+	for i, r := range rs {
+		if string(r) == nameSeparator {
+			b.WriteRune(sep)
+			continue
+		}
+		if unicode.IsUpper(r) && i > 0 {
+			prev := rs[i-1]
+			nextLower := i+1 < len(rs) && unicode.IsLower(rs[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextLower) {
+				b.WriteRune(sep)
+			}
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+	return b.String()
+}
+
+// truncateName bounds name to max bytes (max <= 0 means unlimited) by keeping
+// a prefix and appending "…" plus four hex digits of an FNV-1a hash of the
+// full name. The cut never splits a UTF-8 sequence. If max is smaller than
+// the suffix, only the suffix is returned.
+func truncateName(name string, max int) string {
+	if max <= 0 || len(name) <= max {
+		return name
+	}
+	h := fnv.New32a()
+	h.Write([]byte(name))
+	suffix := fmt.Sprintf("…%04x", h.Sum32()&0xffff)
+
+	keep := max - len(suffix)
+	if keep < 0 {
+		keep = 0
+	}
+	for keep > 0 && !utf8.RuneStart(name[keep]) {
+		keep--
+	}
+	return name[:keep] + suffix
+}
+
+// aliasMemo is the hash of the TypeAliases map byType saw last.
+type aliasMemo struct {
+	// m is kept so its address cannot be reused by another map while memoized.
+	m map[string]string
+	// h is hashAliases(m).
+	h uint64
+}
+
+// lastAliases memoizes aliasesHash for the configuration in use, which
+// typically carries the same TypeAliases map for the life of the process.
+var lastAliases atomic.Pointer[aliasMemo]
+
+// aliasesHash returns hashAliases(m), computing it once per map: TypeAliases
+// must not be mutated once its Config is published, so the map's identity
+// stands for its content. Only the last map is remembered, so alternating
+// configurations with different maps hash on each switch.
+func aliasesHash(m map[string]string) uint64 {
+	if len(m) == 0 {
+		return 0
+	}
+	ptr := reflect.ValueOf(m).UnsafePointer()
+	if p := lastAliases.Load(); p != nil && reflect.ValueOf(p.m).UnsafePointer() == ptr {
+		return p.h
+	}
+	h := hashAliases(m)
+	lastAliases.Store(&aliasMemo{m: m, h: h})
+	return h
+}
+
+// hashAliases returns a stable, order-independent FNV-1a hash of m.
+// An empty map hashes to 0.
+func hashAliases(m map[string]string) uint64 {
+	if len(m) == 0 {
+		return 0
+	}
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	h := fnv.New64a()
+	for _, k := range keys {
+		h.Write([]byte(k))
+		h.Write([]byte{0})
+		h.Write([]byte(m[k]))
+		h.Write([]byte{0})
+	}
+	return h.Sum64()
+}
+
+// CacheStats reports the state of the reflect strategy's name cache.
+type CacheStats struct {
+	// Size is the current number of cached names.
+	Size uint64
+	// Hits estimates lookups answered from the cache. Hits are sampled, so
+	// the value is approximate and moves in steps of the sample rate.
+	Hits uint64
+	// Misses counts lookups that had to compute the name.
+	Misses uint64
+	// Evictions counts entries dropped to honour the cache limit.
+	Evictions uint64
+}
+
+var (
+	// cacheLimit caps the number of cached names; 0 means unbounded.
+	cacheLimit atomic.Int64
+	// cacheSize tracks the number of entries in typeNameCache.
+	cacheSize atomic.Int64
+	// cacheHits, cacheMisses and cacheEvictions feed ReflectCacheStats.
+	cacheHits, cacheMisses, cacheEvictions atomic.Uint64
+
+	// cacheEvicting ensures only one goroutine sweeps the cache at a time.
+	cacheEvicting atomic.Bool
+)
+
+// ReflectCacheStats returns a snapshot of the reflect cache counters.
+// Counters are cumulative for the process.
+func ReflectCacheStats() CacheStats {
+	size := cacheSize.Load()
+	if size < 0 {
+		size = 0
+	}
+	return CacheStats{
+		Size:      uint64(size),
+		Hits:      cacheHits.Load(),
+		Misses:    cacheMisses.Load(),
+		Evictions: cacheEvictions.Load(),
+	}
+}
+
+// SetReflectCacheLimit caps the number of names kept by the reflect strategy's
+// cache. When the cap is exceeded, arbitrary entries are evicted in a batch
+// until the cache is back to about 7/8 of the cap.
+//
+// n <= 0 removes the cap. Entries above a lowered cap are not evicted at
+// once; they go as new names are stored.
+func SetReflectCacheLimit(n int) {
+	if n < 0 {
+		n = 0
+	}
+	cacheLimit.Store(int64(n))
+}
+
+// hitSampleRate is the inverse probability with which a cache hit is counted.
+const hitSampleRate = 64
+
+// cacheStore stores name under key, evicting entries beyond the cache limit.
+// Eviction runs only when the limit is crossed and drops a batch of entries,
+// so the next sweep happens after roughly limit/8 further inserts.
+func cacheStore(key cacheKey, name string) {
+	if _, loaded := typeNameCache.LoadOrStore(key, name); loaded {
+		return
+	}
+	size := cacheSize.Add(1)
+	limit := cacheLimit.Load()
+	if limit <= 0 || size <= limit {
+		return
+	}
+	if !cacheEvicting.CompareAndSwap(false, true) {
+		return // another goroutine is already sweeping
+	}
+	defer cacheEvicting.Store(false)
+
+	target := limit - limit/8
+	typeNameCache.Range(func(k, _ any) bool {
+		if k.(cacheKey) == key {
+			return true // keep the entry just stored
+		}
+		if _, ok := typeNameCache.LoadAndDelete(k); ok {
+			cacheSize.Add(-1)
+			cacheEvictions.Add(1)
+		}
+		return cacheSize.Load() > target
+	})
 }
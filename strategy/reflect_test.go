@@ -19,8 +19,12 @@ package strategy
 import (
 	"reflect"
 	"runtime"
+	"strings"
 	"sync"
 	"testing"
+	"time"
+	"unicode/utf8"
+	"unsafe"
 
 	"dirpx.dev/rfx/apis"
 )
@@ -270,3 +274,573 @@ func BenchmarkReflectStrategy_ByValue(b *testing.B) {
 		s.TryResolve(v, conf)
 	}
 }
+
+func TestReflectStrategy_TypeAliases(t *testing.T) {
+	s := NewReflectStrategy()
+
+	full := cfg(func(c *apis.Config) {
+		c.TypeAliases = map[string]string{"time.Time": "timestamp", "time.Duration": "duration"}
+	})
+	short := cfg(func(c *apis.Config) {
+		c.TypeAliases = map[string]string{"strategy.A": "a"}
+	})
+	byFullPath := cfg(func(c *apis.Config) {
+		c.TypeAliases = map[string]string{"dirpx.dev/rfx/strategy.A": "full.a", "strategy.A": "short.a"}
+	})
+
+	cases := []struct {
+		name     string
+		val      any
+		cfg      apis.Config
+		expected string
+	}{
+		{"time.Time remapped", time.Time{}, full, "timestamp"},
+		{"*time.Time remapped", &time.Time{}, full, "timestamp"},
+		{"time.Duration remapped", time.Duration(0), full, "duration"},
+		{"unaliased type untouched", A{}, full, "strategy.A"},
+		{"no aliases", time.Time{}, cfg(), "time.Time"},
+		{"short key", []A{}, short, "a"},
+		{"full path wins", A{}, byFullPath, "full.a"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got, _ := s.TryResolve(tc.val, tc.cfg); got != tc.expected {
+				t.Fatalf("got %q, want %q", got, tc.expected)
+			}
+		})
+	}
+}
+
+func TestHashAliases_Stable(t *testing.T) {
+	a := map[string]string{"x": "1", "y": "2", "z": "3"}
+	b := map[string]string{"z": "3", "y": "2", "x": "1"}
+	if hashAliases(a) != hashAliases(b) {
+		t.Fatalf("hash depends on map construction order")
+	}
+	if hashAliases(nil) != 0 || hashAliases(map[string]string{}) != 0 {
+		t.Fatalf("empty aliases must hash to 0")
+	}
+	if hashAliases(a) == hashAliases(map[string]string{"x": "1", "y": "2", "z": "4"}) {
+		t.Fatalf("different aliases must hash differently")
+	}
+}
+
+func TestAliasesHash_MemoizedPerMap(t *testing.T) {
+	// Enough entries that hashing has to allocate its key slice.
+	a := map[string]string{
+		"strategy.A": "a", "strategy.B": "b", "strategy.C": "c", "strategy.D": "d",
+		"strategy.E": "e", "strategy.F": "f", "strategy.G": "g", "strategy.H": "h",
+	}
+	b := map[string]string{"strategy.A": "b"}
+	if aliasesHash(a) != hashAliases(a) || aliasesHash(b) != hashAliases(b) || aliasesHash(a) != hashAliases(a) {
+		t.Fatal("aliasesHash disagrees with hashAliases")
+	}
+
+	c := cfg(func(c *apis.Config) { c.TypeAliases = a })
+	typ := reflect.TypeOf(A{})
+	_ = byType(typ, c)
+	if n := testing.AllocsPerRun(100, func() { _ = byType(typ, c) }); n != 0 {
+		t.Fatalf("cached byType with aliases allocates %v times, want 0", n)
+	}
+}
+
+func TestReflectStrategy_UnsafeKindReject(t *testing.T) {
+	s := NewReflectStrategy()
+	reject := cfg(func(c *apis.Config) { c.UnsafeKinds = apis.UnsafeKindReject })
+
+	if got, _ := s.TryResolve(uintptr(1), cfg()); got != "uintptr" {
+		t.Fatalf("default uintptr: got %q, want %q", got, "uintptr")
+	}
+	if got, _ := s.TryResolve(uintptr(1), reject); got != "" {
+		t.Fatalf("rejected uintptr: got %q, want empty", got)
+	}
+	if got, _ := s.TryResolve(unsafe.Pointer(nil), cfg()); got != "unsafe.Pointer" {
+		t.Fatalf("default unsafe.Pointer: got %q, want %q", got, "unsafe.Pointer")
+	}
+	if got, _ := s.TryResolve(unsafe.Pointer(nil), reject); got != "" {
+		t.Fatalf("rejected unsafe.Pointer: got %q, want empty", got)
+	}
+	if got, _ := s.TryResolve(A{}, reject); got != "strategy.A" {
+		t.Fatalf("regular type: got %q, want %q", got, "strategy.A")
+	}
+}
+
+func TestReflectStrategy_TrimModuleVersion(t *testing.T) {
+	s := NewReflectStrategy()
+	trim := cfg(func(c *apis.Config) { c.TrimModuleVersion = true })
+
+	// Unversioned paths are unaffected.
+	for _, c := range []apis.Config{cfg(), trim} {
+		if got, _ := s.TryResolve(A{}, c); got != "strategy.A" {
+			t.Errorf("unversioned: got %q, want %q", got, "strategy.A")
+		}
+	}
+	if k1, k2 := (cacheKey{t: reflect.TypeOf(A{})}), (cacheKey{t: reflect.TypeOf(A{}), trimModVer: true}); k1 == k2 {
+		t.Fatal("TrimModuleVersion must be part of the cache key")
+	}
+}
+
+func TestCollapseSeparators(t *testing.T) {
+	cases := map[string]string{
+		"":               "",
+		"pkg.Type":       "pkg.Type",
+		"pkg..Type":      "pkg.Type",
+		".Type":          "Type",
+		"pkg.":           "pkg",
+		"..a...b..":      "a.b",
+		".":              "",
+		"map[string]x.Y": "map[string]x.Y",
+	}
+	for in, want := range cases {
+		if got := collapseSeparators(in); got != want {
+			t.Errorf("collapseSeparators(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestReflectStrategy_NoEmptySegments(t *testing.T) {
+	s := NewReflectStrategy()
+	aliases := cfg(func(c *apis.Config) {
+		c.TypeAliases = map[string]string{"strategy.A": ".legacy..a.", "strategy.Order": "."}
+	})
+
+	if got, _ := s.TryResolve(A{}, aliases); got != "legacy.a" {
+		t.Fatalf("alias with empty segments: got %q, want %q", got, "legacy.a")
+	}
+	if got, _ := s.TryResolve(Order{}, aliases); got != "" {
+		t.Fatalf("separator-only alias: got %q, want empty", got)
+	}
+	// Markers are applied to the cleaned name.
+	markers := aliases
+	markers.KeepContainerMarkers = true
+	if got, _ := s.TryResolve([]*A{}, markers); got != "[]*legacy.a" {
+		t.Fatalf("markers: got %q, want %q", got, "[]*legacy.a")
+	}
+}
+
+type rawBytes []byte
+
+func TestReflectStrategy_FriendlyByteSlices(t *testing.T) {
+	s := NewReflectStrategy()
+	friendly := cfg(func(c *apis.Config) { c.FriendlyByteSlices = true })
+
+	cases := []struct {
+		v           any
+		off, wanted string
+	}{
+		{[]byte("x"), "uint8", BytesName},
+		{[]uint8{}, "uint8", BytesName},
+		{[]rune("x"), "int32", RunesName},
+		{[]int32{}, "int32", RunesName},
+		{rawBytes{}, "uint8", "uint8"}, // defined slice types keep the default
+		{[]int{}, "int", "int"},
+	}
+	for _, tc := range cases {
+		if got, _ := s.TryResolve(tc.v, cfg()); got != tc.off {
+			t.Errorf("off %T: got %q, want %q", tc.v, got, tc.off)
+		}
+		if got, _ := s.TryResolve(tc.v, friendly); got != tc.wanted {
+			t.Errorf("on %T: got %q, want %q", tc.v, got, tc.wanted)
+		}
+	}
+}
+
+func TestReflectStrategy_PkgPathHashSuffix(t *testing.T) {
+	s := NewReflectStrategy()
+	hashed := cfg(func(c *apis.Config) { c.PkgPathHashSuffix = true })
+
+	if a, b := pkgPathHash("a/util"), pkgPathHash("b/util"); a == b || len(a) != 4 {
+		t.Fatalf("pkgPathHash: a/util=%q b/util=%q, want distinct 4-hex suffixes", a, b)
+	}
+
+	suffix := "." + pkgPathHash(reflect.TypeOf(A{}).PkgPath())
+	if got, _ := s.TryResolve(A{}, hashed); got != "strategy.A"+suffix {
+		t.Fatalf("A: got %q, want %q", got, "strategy.A"+suffix)
+	}
+	// Same package, same suffix.
+	if got, _ := s.TryResolve(Order{}, hashed); got != "strategy.Order"+suffix {
+		t.Fatalf("Order: got %q, want %q", got, "strategy.Order"+suffix)
+	}
+	// Builtins have no package path and get no suffix.
+	if got, _ := s.TryResolve(0, hashed); got != "int" {
+		t.Fatalf("int: got %q, want %q", got, "int")
+	}
+	if got, _ := s.TryResolve(A{}, cfg()); got != "strategy.A" {
+		t.Fatalf("off: got %q, want %q", got, "strategy.A")
+	}
+}
+
+type Order struct{}
+
+func TestReflectStrategy_OmitPackage(t *testing.T) {
+	s := NewReflectStrategy()
+	omit := cfg(func(c *apis.Config) { c.OmitPackage = true })
+
+	if got, _ := s.TryResolve(Order{}, cfg()); got != "strategy.Order" {
+		t.Fatalf("default: got %q, want %q", got, "strategy.Order")
+	}
+	if got, _ := s.TryResolve(&Order{}, omit); got != "Order" {
+		t.Fatalf("OmitPackage: got %q, want %q", got, "Order")
+	}
+	// Builtins are unaffected.
+	if got, _ := s.TryResolve(0, omit); got != "int" {
+		t.Fatalf("OmitPackage builtin: got %q, want %q", got, "int")
+	}
+}
+
+func TestReflectStrategy_UnsafeKinds(t *testing.T) {
+	s := NewReflectStrategy()
+	fixed := cfg(func(c *apis.Config) { c.UnsafeKinds = apis.UnsafeKindFixedName })
+	failing := cfg(func(c *apis.Config) { c.UnsafeKinds = apis.UnsafeKindError })
+
+	for _, v := range []any{uintptr(1), unsafe.Pointer(nil), []uintptr{}} {
+		if got, _ := s.TryResolve(v, fixed); got != UnsafeKindName {
+			t.Errorf("fixed %T: got %q, want %q", v, got, UnsafeKindName)
+		}
+		if got, _ := s.TryResolve(v, failing); got != "" {
+			t.Errorf("error %T: got %q, want empty", v, got)
+		}
+	}
+	// Without builtins, uintptr is still named under the fixed policy.
+	noBuiltins := cfg(func(c *apis.Config) { c.UnsafeKinds = apis.UnsafeKindFixedName; c.IncludeBuiltins = false })
+	if got, _ := s.TryResolve(uintptr(1), noBuiltins); got != UnsafeKindName {
+		t.Errorf("fixed uintptr without builtins: got %q, want %q", got, UnsafeKindName)
+	}
+	// The deprecated RejectUnsafeKinds wins over the policy.
+	both := cfg(func(c *apis.Config) { c.UnsafeKinds = apis.UnsafeKindFixedName; c.RejectUnsafeKinds = true })
+	if got, _ := s.TryResolve(uintptr(1), both); got != "" {
+		t.Errorf("rejected uintptr: got %q, want empty", got)
+	}
+	for _, c := range []apis.Config{fixed, failing} {
+		if got, _ := s.TryResolve(A{}, c); got != "strategy.A" {
+			t.Errorf("regular type: got %q, want %q", got, "strategy.A")
+		}
+	}
+}
+
+type AVeryLongTypeNameUsedToExerciseTruncationOne struct{}
+type AVeryLongTypeNameUsedToExerciseTruncationTwo struct{}
+
+func TestReflectStrategy_MaxNameLen(t *testing.T) {
+	s := NewReflectStrategy()
+	limited := cfg(func(c *apis.Config) { c.MaxNameLen = 24 })
+
+	one, _ := s.TryResolve(AVeryLongTypeNameUsedToExerciseTruncationOne{}, limited)
+	two, _ := s.TryResolve(AVeryLongTypeNameUsedToExerciseTruncationTwo{}, limited)
+
+	if len(one) > 24 || len(two) > 24 {
+		t.Fatalf("names exceed limit: %q (%d), %q (%d)", one, len(one), two, len(two))
+	}
+	if !strings.HasPrefix(one, "strategy.AVery") || !strings.Contains(one, "…") {
+		t.Fatalf("unexpected truncated form: %q", one)
+	}
+	if one == two {
+		t.Fatalf("distinct long names truncated to the same value: %q", one)
+	}
+	if again := truncateName("strategy.AVeryLongTypeNameUsedToExerciseTruncationOne", 24); again != one {
+		t.Fatalf("truncation is not deterministic: %q vs %q", again, one)
+	}
+
+	// Short names and unlimited configs are untouched.
+	if got, _ := s.TryResolve(A{}, limited); got != "strategy.A" {
+		t.Fatalf("short name: got %q, want %q", got, "strategy.A")
+	}
+	full, _ := s.TryResolve(AVeryLongTypeNameUsedToExerciseTruncationOne{}, cfg())
+	if full != "strategy.AVeryLongTypeNameUsedToExerciseTruncationOne" {
+		t.Fatalf("unlimited: got %q", full)
+	}
+}
+
+func TestTruncateName_RuneBoundary(t *testing.T) {
+	got := truncateName("pkg.ÄÄÄÄÄÄÄÄÄÄ", 12)
+	if !utf8.ValidString(got) || len(got) > 12 {
+		t.Fatalf("truncateName produced %q (%d bytes)", got, len(got))
+	}
+}
+
+func TestReflectStrategy_KeepContainerMarkers(t *testing.T) {
+	s := NewReflectStrategy()
+	keep := cfg(func(c *apis.Config) { c.KeepContainerMarkers = true })
+
+	cases := []struct {
+		v    any
+		want string
+	}{
+		{[]*A{}, "[]*strategy.A"},
+		{&A{}, "*strategy.A"},
+		{[3]A{}, "[3]strategy.A"},
+		{make(<-chan A), "<-chan strategy.A"},
+		{map[string]A{}, "map[string]strategy.A"},
+		{map[A][]int{}, "map[strategy.A][]int"},
+		{A{}, "strategy.A"},
+	}
+	for _, tc := range cases {
+		if got, _ := s.TryResolve(tc.v, keep); got != tc.want {
+			t.Errorf("%T: got %q, want %q", tc.v, got, tc.want)
+		}
+	}
+
+	// The flag is part of the cache key: the collapsed form is unaffected.
+	if got, _ := s.TryResolve([]*A{}, cfg()); got != "strategy.A" {
+		t.Fatalf("default config: got %q, want %q", got, "strategy.A")
+	}
+}
+
+type OrderList []A
+
+func TestReflectStrategy_NormalizeOutermost(t *testing.T) {
+	s := NewReflectStrategy()
+	outer := cfg(func(c *apis.Config) { c.NormalizeOutermost = true })
+
+	if got, _ := s.TryResolve(OrderList{}, outer); got != "strategy.OrderList" {
+		t.Fatalf("outermost OrderList: got %q", got)
+	}
+	if got, _ := s.TryResolve([]A{}, outer); got != "strategy.A" {
+		t.Fatalf("outermost []A: got %q", got)
+	}
+	// The flag is part of the cache key.
+	if got, _ := s.TryResolve(OrderList{}, cfg()); got != "strategy.A" {
+		t.Fatalf("innermost OrderList: got %q", got)
+	}
+}
+
+func TestReflectStrategy_KeepArrayLen(t *testing.T) {
+	s := NewReflectStrategy()
+	keep := cfg(func(c *apis.Config) { c.KeepArrayLen = true })
+
+	cases := []struct {
+		v    any
+		want string
+	}{
+		{[2]A{}, "strategy.A[2]"},
+		{[3]A{}, "strategy.A[3]"},
+		{[]A{}, "strategy.A"},
+		{&[2]A{}, "strategy.A"}, // the outermost container is a pointer
+	}
+	for _, tc := range cases {
+		if got, _ := s.TryResolve(tc.v, keep); got != tc.want {
+			t.Errorf("%T: got %q, want %q", tc.v, got, tc.want)
+		}
+	}
+	if got, _ := s.TryResolve([2]A{}, cfg()); got != "strategy.A" {
+		t.Fatalf("default config: got %q, want %q", got, "strategy.A")
+	}
+}
+
+func TestReflectStrategy_DistinguishArrays(t *testing.T) {
+	s := NewReflectStrategy()
+	on := cfg(func(c *apis.Config) { c.DistinguishArrays = true })
+
+	b16, _ := s.TryResolve([16]byte{}, on)
+	b32, _ := s.TryResolve([32]byte{}, on)
+	if b16 != "[16]uint8" || b32 != "[32]uint8" {
+		t.Fatalf("enabled: got %q and %q", b16, b32)
+	}
+	if got, _ := s.TryResolve(&[16]byte{}, on); got != "uint8" {
+		t.Fatalf("nested array: got %q, want %q", got, "uint8")
+	}
+	if got, _ := s.TryResolve([2]A{}, on); got != "[2]strategy.A" {
+		t.Fatalf("named element: got %q", got)
+	}
+
+	off16, _ := s.TryResolve([16]byte{}, cfg())
+	off32, _ := s.TryResolve([32]byte{}, cfg())
+	if off16 != off32 {
+		t.Fatalf("disabled: want equal names, got %q and %q", off16, off32)
+	}
+}
+
+type cacheStatsType struct{}
+
+func TestReflectCacheStats_HitsAndMisses(t *testing.T) {
+	s := NewReflectStrategy()
+	c := cfg(func(c *apis.Config) { c.MaxNameLen = 1001 }) // key unique to this test
+
+	const lookups = 100 * hitSampleRate
+
+	before := ReflectCacheStats()
+	s.TryResolve(cacheStatsType{}, c) // miss
+	for i := 0; i < lookups; i++ {
+		s.TryResolve(cacheStatsType{}, c) // hit
+	}
+	after := ReflectCacheStats()
+
+	if d := after.Misses - before.Misses; d != 1 {
+		t.Fatalf("misses moved by %d, want 1", d)
+	}
+	// Hits are sampled; the estimate stays well within a factor of two.
+	if d := after.Hits - before.Hits; d < lookups/2 || d > lookups*2 || d%hitSampleRate != 0 {
+		t.Fatalf("hits moved by %d, want about %d", d, lookups)
+	}
+	if after.Size != before.Size+1 {
+		t.Fatalf("size = %d, want %d", after.Size, before.Size+1)
+	}
+}
+
+func TestSetReflectCacheLimit_Evicts(t *testing.T) {
+	s := NewReflectStrategy()
+	defer SetReflectCacheLimit(0)
+
+	before := ReflectCacheStats()
+	limit := int(before.Size) + 1
+	SetReflectCacheLimit(limit)
+
+	for i := 0; i < 3; i++ {
+		n := 2000 + i
+		s.TryResolve(cacheStatsType{}, cfg(func(c *apis.Config) { c.MaxNameLen = n }))
+	}
+	after := ReflectCacheStats()
+
+	if after.Size > uint64(limit) {
+		t.Fatalf("size %d exceeds limit %d", after.Size, limit)
+	}
+	if d := after.Evictions - before.Evictions; d < 2 {
+		t.Fatalf("evictions moved by %d, want >= 2", d)
+	}
+	// Names are still correct after eviction.
+	if got, _ := s.TryResolve(cacheStatsType{}, cfg()); got != "strategy.cacheStatsType" {
+		t.Fatalf("got %q after eviction", got)
+	}
+}
+
+func TestReflectStrategy_DistinguishPointers(t *testing.T) {
+	s := NewReflectStrategy()
+	single := cfg(func(c *apis.Config) { c.DistinguishPointers = true })
+	depth := cfg(func(c *apis.Config) { c.DistinguishPointers, c.PointerDepthInName = true, true })
+	pp := func() **A { p := &A{}; return &p }()
+
+	cases := []struct {
+		name string
+		v    any
+		c    apis.Config
+		want string
+	}{
+		{"ptr", &A{}, single, "*strategy.A"},
+		{"ptr-ptr collapsed", pp, single, "*strategy.A"},
+		{"ptr with depth", &A{}, depth, "*strategy.A"},
+		{"ptr-ptr with depth", pp, depth, "**strategy.A"},
+		{"ptr inside slice", []*A{}, depth, "strategy.A"},
+		{"value", A{}, depth, "strategy.A"},
+		{"depth alone is inert", pp, cfg(func(c *apis.Config) { c.PointerDepthInName = true }), "strategy.A"},
+	}
+	for _, tc := range cases {
+		if got, _ := s.TryResolve(tc.v, tc.c); got != tc.want {
+			t.Errorf("%s: got %q, want %q", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestReflectStrategy_KeepPointerDepth(t *testing.T) {
+	s := NewReflectStrategy()
+	keep := cfg(func(c *apis.Config) { c.KeepPointerDepth = true })
+	pp := func() **A { p := &A{}; return &p }()
+
+	cases := []struct {
+		v    any
+		want string
+	}{
+		{&A{}, "*strategy.A"},
+		{pp, "**strategy.A"},
+		{&[]A{}, "*strategy.A"},
+		{A{}, "strategy.A"},
+	}
+	for _, tc := range cases {
+		if got, _ := s.TryResolve(tc.v, keep); got != tc.want {
+			t.Errorf("%T: got %q, want %q", tc.v, got, tc.want)
+		}
+	}
+	if got, _ := s.TryResolve(pp, cfg()); got != "strategy.A" {
+		t.Fatalf("default config: got %q, want %q", got, "strategy.A")
+	}
+}
+
+func TestReflectName_MatchesStrategyWithoutCache(t *testing.T) {
+	s := NewReflectStrategy()
+	types := []reflect.Type{
+		reflect.TypeOf(A{}),
+		reflect.TypeOf(&A{}),
+		reflect.TypeOf([]*A{}),
+		reflect.TypeOf(map[string]A{}),
+		reflect.TypeOf(W[int]{}),
+		reflect.TypeOf([]byte{}),
+		reflect.TypeOf(0),
+		reflect.TypeOf(struct{}{}),
+	}
+	configs := []apis.Config{
+		cfg(),
+		cfg(func(c *apis.Config) { c.IncludeBuiltins = false }),
+		cfg(func(c *apis.Config) { c.KeepContainerMarkers = true }),
+		cfg(func(c *apis.Config) { c.FriendlyByteSlices = true; c.OmitPackage = true }),
+		cfg(func(c *apis.Config) { c.TypeAliases = map[string]string{"strategy.A": "alias.A"} }),
+	}
+
+	for _, c := range configs {
+		for _, typ := range types {
+			want, _ := s.TryResolveType(typ, c)
+			before := ReflectCacheStats()
+			got, ok := ReflectName(typ, c)
+			if after := ReflectCacheStats(); after != before {
+				t.Fatalf("ReflectName(%v) touched the cache: %+v -> %+v", typ, before, after)
+			}
+			if got != want || ok != (want != "") {
+				t.Errorf("ReflectName(%v, %+v) = (%q, %v), strategy = %q", typ, c, got, ok, want)
+			}
+		}
+	}
+
+	if got, ok := ReflectName(nil, cfg()); got != "" || ok {
+		t.Fatalf("ReflectName(nil) = (%q, %v), want (\"\", false)", got, ok)
+	}
+}
+
+type HTTPServerConfig struct{}
+type UserAccount[T any] struct{}
+
+func TestReflectStrategy_NameStyle(t *testing.T) {
+	s := NewReflectStrategy()
+	snake := func(c *apis.Config) { c.NameStyle = apis.NameStyleSnakeCase }
+	kebab := func(c *apis.Config) { c.NameStyle = apis.NameStyleKebabCase }
+	ptrs := func(c *apis.Config) { c.DistinguishPointers = true }
+
+	cases := []struct {
+		name string
+		val  any
+		cfg  apis.Config
+		want string
+	}{
+		{"dotted default", UserAccount[int]{}, cfg(), "strategy.UserAccount"},
+		{"snake generic", UserAccount[int]{}, cfg(snake), "strategy_user_account"},
+		{"kebab generic", UserAccount[string]{}, cfg(kebab), "strategy-user-account"},
+		{"snake acronym", HTTPServerConfig{}, cfg(snake), "strategy_http_server_config"},
+		{"snake pointer", &HTTPServerConfig{}, cfg(snake), "strategy_http_server_config"},
+		{"snake pointer marker", &UserAccount[int]{}, cfg(snake, ptrs), "*strategy_user_account"},
+		{"kebab pointer marker", &HTTPServerConfig{}, cfg(kebab, ptrs), "*strategy-http-server-config"},
+		{"snake builtin", 0, cfg(snake), "int"},
+		{"snake alias kept", A{}, cfg(snake, func(c *apis.Config) {
+			c.TypeAliases = map[string]string{"strategy.A": "Alias.A"}
+		}), "Alias.A"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got, _ := s.TryResolve(tc.val, tc.cfg); got != tc.want {
+				t.Fatalf("got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestReflectStrategy_UnwrapFinalNamed(t *testing.T) {
+	s := NewReflectStrategy()
+	one := cfg(func(c *apis.Config) { c.MaxUnwrap = 1 })
+	final := cfg(func(c *apis.Config) { c.MaxUnwrap = 1; c.UnwrapFinalNamed = true })
+	ppA := reflect.TypeOf((**A)(nil))
+
+	// Resolve without the option first so a cache key missing the knob
+	// would leak "" into the second call.
+	if got, _ := s.TryResolveType(ppA, one); got != "" {
+		t.Fatalf("MaxUnwrap=1: got %q, want empty", got)
+	}
+	if got, _ := s.TryResolveType(ppA, final); got != "strategy.A" {
+		t.Fatalf("MaxUnwrap=1 with UnwrapFinalNamed: got %q, want strategy.A", got)
+	}
+}
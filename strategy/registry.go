@@ -35,6 +35,9 @@ type registryStrategy struct {
 // Ensure registryStrategy implements strategy.Strategy.
 var _ apis.Strategy = (*registryStrategy)(nil)
 
+// Name returns "registry".
+func (*registryStrategy) Name() string { return "registry" }
+
 // TryResolve looks up v's type in the registry.
 func (s *registryStrategy) TryResolve(v any, _ apis.Config) (string, bool) {
 	if v == nil || s.reg == nil {
@@ -0,0 +1,86 @@
+/*
+   Copyright 2025 The DIRPX Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package strategy
+
+import (
+	"errors"
+	"reflect"
+	"sort"
+
+	"dirpx.dev/rfx/apis"
+)
+
+// NewSentinelErrorStrategy creates an apis.Strategy that names well-known
+// sentinel errors (e.g. var ErrNotFound = errors.New(...)) from m.
+// A value is matched by identity first, then by errors.Is against each key,
+// so wrapped sentinels resolve too. When several keys match via errors.Is,
+// the one with the lexically smallest Error() text wins. Non-error values and
+// unmatched errors fall through. The map is copied.
+func NewSentinelErrorStrategy(m map[error]string) apis.Strategy {
+	s := &sentinelErrorStrategy{
+		exact: make(map[error]string, len(m)),
+		keys:  make([]error, 0, len(m)),
+	}
+	for err, name := range m {
+		if err == nil || name == "" {
+			continue
+		}
+		s.exact[err] = name
+		s.keys = append(s.keys, err)
+	}
+	sort.Slice(s.keys, func(i, j int) bool { return s.keys[i].Error() < s.keys[j].Error() })
+	return s
+}
+
+// sentinelErrorStrategy resolves names of sentinel error values.
+type sentinelErrorStrategy struct {
+	exact map[error]string
+	keys  []error
+}
+
+// Ensure sentinelErrorStrategy implements apis.Strategy.
+var _ apis.Strategy = (*sentinelErrorStrategy)(nil)
+
+// Name returns "sentinel-error".
+func (*sentinelErrorStrategy) Name() string { return "sentinel-error" }
+
+// TypeResolvable returns false: sentinels are identified by value.
+func (*sentinelErrorStrategy) TypeResolvable() bool { return false }
+
+// TryResolve matches v against the sentinel errors.
+func (s *sentinelErrorStrategy) TryResolve(v any, _ apis.Config) (string, bool) {
+	err, ok := v.(error)
+	if !ok || err == nil {
+		return "", false
+	}
+	if reflect.TypeOf(err).Comparable() {
+		if name, ok := s.exact[err]; ok {
+			return name, true
+		}
+	}
+	for _, k := range s.keys {
+		if errors.Is(err, k) {
+			return s.exact[k], true
+		}
+	}
+	return "", false
+}
+
+// TryResolveType always returns false: sentinels are identified by value.
+func (*sentinelErrorStrategy) TryResolveType(_ reflect.Type, _ apis.Config) (string, bool) {
+	return "", false
+}
@@ -0,0 +1,63 @@
+/*
+   Copyright 2025 The DIRPX Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package strategy_test
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"dirpx.dev/rfx/strategy"
+)
+
+var (
+	errNotFound = errors.New("not found")
+	errConflict = errors.New("conflict")
+)
+
+func TestSentinelErrorStrategy(t *testing.T) {
+	s := strategy.NewSentinelErrorStrategy(map[error]string{
+		errNotFound: "error.not_found",
+		errConflict: "error.conflict",
+	})
+
+	cases := []struct {
+		name string
+		v    any
+		want string
+		ok   bool
+	}{
+		{"identity", errNotFound, "error.not_found", true},
+		{"wrapped", fmt.Errorf("load user: %w", errConflict), "error.conflict", true},
+		{"unknown error", errors.New("not found"), "", false},
+		{"non-error", "not found", "", false},
+		{"nil", nil, "", false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, ok := s.TryResolve(tc.v, cfg())
+			if got != tc.want || ok != tc.ok {
+				t.Fatalf("TryResolve = (%q,%v), want (%q,%v)", got, ok, tc.want, tc.ok)
+			}
+		})
+	}
+
+	if _, ok := s.TryResolveType(reflect.TypeOf(errNotFound), cfg()); ok {
+		t.Fatal("TryResolveType must fall through")
+	}
+}
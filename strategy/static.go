@@ -0,0 +1,67 @@
+/*
+   Copyright 2025 The DIRPX Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package strategy
+
+import (
+	"reflect"
+
+	"dirpx.dev/rfx/apis"
+)
+
+// NewStaticMapStrategy creates an apis.Strategy that resolves names by exact
+// type lookup in m, typically a map produced by code generation at init.
+// The map is copied, so later changes to m have no effect and lookups need
+// no locking. Containers are NOT normalized: *T and []T only match if they
+// are keys themselves. Place it before the reflect fallback.
+func NewStaticMapStrategy(m map[reflect.Type]string) apis.Strategy {
+	cp := make(map[reflect.Type]string, len(m))
+	for t, name := range m {
+		if t != nil && name != "" {
+			cp[t] = name
+		}
+	}
+	return &staticMapStrategy{m: cp}
+}
+
+// staticMapStrategy looks up names in an immutable, exact-type map.
+type staticMapStrategy struct {
+	m map[reflect.Type]string
+}
+
+// Ensure staticMapStrategy implements apis.Strategy.
+var _ apis.Strategy = (*staticMapStrategy)(nil)
+
+// Name returns "static-map".
+func (*staticMapStrategy) Name() string { return "static-map" }
+
+// TryResolve looks up v's exact type in the map.
+func (s *staticMapStrategy) TryResolve(v any, _ apis.Config) (string, bool) {
+	if v == nil {
+		return "", false
+	}
+	name, ok := s.m[reflect.TypeOf(v)]
+	return name, ok
+}
+
+// TryResolveType looks up t in the map.
+func (s *staticMapStrategy) TryResolveType(t reflect.Type, _ apis.Config) (string, bool) {
+	if t == nil {
+		return "", false
+	}
+	name, ok := s.m[t]
+	return name, ok
+}
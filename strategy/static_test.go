@@ -0,0 +1,62 @@
+/*
+   Copyright 2025 The DIRPX Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package strategy_test
+
+import (
+	"reflect"
+	"testing"
+
+	"dirpx.dev/rfx/strategy"
+)
+
+func TestStaticMapStrategy_ExactLookups(t *testing.T) {
+	m := map[reflect.Type]string{reflect.TypeOf(A{}): "gen.A"}
+	s := strategy.NewStaticMapStrategy(m)
+
+	if got, ok := s.TryResolve(A{}, cfg()); !ok || got != "gen.A" {
+		t.Fatalf("TryResolve(A{}) = (%q,%v), want (gen.A,true)", got, ok)
+	}
+	if got, ok := s.TryResolveType(reflect.TypeOf(A{}), cfg()); !ok || got != "gen.A" {
+		t.Fatalf("TryResolveType(A) = (%q,%v), want (gen.A,true)", got, ok)
+	}
+
+	// No container normalization.
+	if _, ok := s.TryResolve(&A{}, cfg()); ok {
+		t.Fatal("*A must not match an A key")
+	}
+	if _, ok := s.TryResolve(nil, cfg()); ok {
+		t.Fatal("nil must not match")
+	}
+	if _, ok := s.TryResolveType(nil, cfg()); ok {
+		t.Fatal("nil type must not match")
+	}
+}
+
+func TestStaticMapStrategy_CopiesMap(t *testing.T) {
+	m := map[reflect.Type]string{reflect.TypeOf(A{}): "gen.A"}
+	s := strategy.NewStaticMapStrategy(m)
+
+	m[reflect.TypeOf(A{})] = "changed"
+	m[reflect.TypeOf(G[int]{})] = "gen.G"
+
+	if got, _ := s.TryResolve(A{}, cfg()); got != "gen.A" {
+		t.Fatalf("mutation leaked into strategy: got %q", got)
+	}
+	if _, ok := s.TryResolve(G[int]{}, cfg()); ok {
+		t.Fatal("key added after construction must not be visible")
+	}
+}
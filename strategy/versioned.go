@@ -0,0 +1,85 @@
+/*
+   Copyright 2025 The DIRPX Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package strategy
+
+import (
+	"reflect"
+
+	"dirpx.dev/rfx/apis"
+)
+
+// DefaultVersionSeparator joins EntityName and EntityVersion by default.
+const DefaultVersionSeparator = "@"
+
+// VersionedNamerOption configures a strategy created by NewVersionedNamerStrategy.
+type VersionedNamerOption func(*versionedNamerStrategy)
+
+// WithVersionSeparator sets the separator placed between name and version.
+func WithVersionSeparator(sep string) VersionedNamerOption {
+	return func(s *versionedNamerStrategy) {
+		s.sep = sep
+	}
+}
+
+// NewVersionedNamerStrategy creates an apis.Strategy that qualifies the name of
+// apis.Describer values with their version (e.g. "domain.user@v2").
+// Place it first in a custom builder, before NewNamerStrategy and therefore
+// before the registry and reflect strategies: values that are not Describers,
+// or whose version is empty, fall through, so the plain Namer yields the
+// unsuffixed name. For event-bus style names such as "order.created.v2",
+// use WithVersionSeparator(".").
+func NewVersionedNamerStrategy(opts ...VersionedNamerOption) apis.Strategy {
+	s := &versionedNamerStrategy{sep: DefaultVersionSeparator}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// versionedNamerStrategy returns EntityName() + sep + EntityVersion() for
+// Describer values that report a version.
+type versionedNamerStrategy struct {
+	// sep separates the name from the version.
+	sep string
+}
+
+// Ensure versionedNamerStrategy implements apis.Strategy.
+var _ apis.Strategy = (*versionedNamerStrategy)(nil)
+
+// Name returns "versioned-namer".
+func (*versionedNamerStrategy) Name() string { return "versioned-namer" }
+
+// TryResolve handles Describer values with a non-empty version.
+func (s *versionedNamerStrategy) TryResolve(v any, _ apis.Config) (string, bool) {
+	d, ok := v.(apis.Describer)
+	if !ok {
+		return "", false
+	}
+	ver := d.EntityVersion()
+	if ver == "" {
+		return "", false
+	}
+	return d.EntityName() + s.sep + ver, true
+}
+
+// TypeResolvable returns false: Describer requires an instance.
+func (*versionedNamerStrategy) TypeResolvable() bool { return false }
+
+// TryResolveType always returns false: Describer requires an instance.
+func (*versionedNamerStrategy) TryResolveType(_ reflect.Type, _ apis.Config) (string, bool) {
+	return "", false
+}
@@ -0,0 +1,117 @@
+/*
+   Copyright 2025 The DIRPX Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package strategy_test
+
+import (
+	"reflect"
+	"testing"
+
+	"dirpx.dev/rfx/apis"
+	rfxregistry "dirpx.dev/rfx/registry"
+	"dirpx.dev/rfx/resolver"
+	"dirpx.dev/rfx/strategy"
+)
+
+// describedType implements apis.Describer with a configurable version.
+type describedType struct{ version string }
+
+func (describedType) EntityName() string        { return "domain.user" }
+func (d describedType) EntityVersion() string   { return d.version }
+func (describedType) EntityCategory() string    { return "identity" }
+func (describedType) EntityDescription() string { return "User account" }
+
+func TestVersionedNamerStrategy_TryResolve(t *testing.T) {
+	s := strategy.NewVersionedNamerStrategy()
+	conf := apis.Config{}
+
+	got, ok := s.TryResolve(describedType{version: "v2"}, conf)
+	if !ok || got != "domain.user@v2" {
+		t.Fatalf("TryResolve(v2): got (%q,%v), want (domain.user@v2,true)", got, ok)
+	}
+
+	// Empty version falls through.
+	if got, ok := s.TryResolve(describedType{}, conf); ok || got != "" {
+		t.Fatalf("TryResolve(no version): got (%q,%v), want ('',false)", got, ok)
+	}
+
+	// Plain Namer falls through.
+	if got, ok := s.TryResolve(namedType{}, conf); ok || got != "" {
+		t.Fatalf("TryResolve(namer): got (%q,%v), want ('',false)", got, ok)
+	}
+
+	// No instance -> never handled.
+	if got, ok := s.TryResolveType(reflect.TypeOf(describedType{}), conf); ok || got != "" {
+		t.Fatalf("TryResolveType: got (%q,%v), want ('',false)", got, ok)
+	}
+}
+
+func TestVersionedNamerStrategy_Separator(t *testing.T) {
+	s := strategy.NewVersionedNamerStrategy(strategy.WithVersionSeparator("/"))
+	if got, _ := s.TryResolve(describedType{version: "v3"}, apis.Config{}); got != "domain.user/v3" {
+		t.Fatalf("custom separator: got %q, want %q", got, "domain.user/v3")
+	}
+}
+
+func TestVersionedNamerStrategy_BeforeNamer(t *testing.T) {
+	res := resolver.New(
+		strategy.NewVersionedNamerStrategy(),
+		strategy.NewNamerStrategy(),
+		strategy.NewReflectStrategy(),
+	)
+	conf := apis.Config{IncludeBuiltins: true, MaxUnwrap: 8, MapPreferElem: true}
+
+	if got := res.Resolve(describedType{version: "v2"}, conf); got != "domain.user@v2" {
+		t.Fatalf("Describer: got %q, want %q", got, "domain.user@v2")
+	}
+	if got := res.Resolve(describedType{}, conf); got != "domain.user" {
+		t.Fatalf("Describer without version: got %q, want %q", got, "domain.user")
+	}
+	if got := res.Resolve(namedType{}, conf); got != "custom.Name" {
+		t.Fatalf("plain Namer: got %q, want %q", got, "custom.Name")
+	}
+}
+
+func TestVersionedNamerStrategy_DotSeparatorInFullChain(t *testing.T) {
+	conf := cfg()
+	reg := rfxregistry.New(conf)
+	if err := reg.Register(reflect.TypeOf(A{}), "domain.A"); err != nil {
+		t.Fatalf("Register(A): %v", err)
+	}
+	res := resolver.New(
+		strategy.NewVersionedNamerStrategy(strategy.WithVersionSeparator(".")),
+		strategy.NewNamerStrategy(),
+		strategy.NewRegistryStrategy(reg),
+		strategy.NewReflectStrategy(),
+	)
+
+	if got := res.Resolve(describedType{version: "v2"}, conf); got != "domain.user.v2" {
+		t.Fatalf("with version: got %q, want %q", got, "domain.user.v2")
+	}
+	if got := res.Resolve(describedType{}, conf); got != "domain.user" {
+		t.Fatalf("without version: got %q, want %q", got, "domain.user")
+	}
+	// Non-Describers fall through to the registry and reflect strategies.
+	if got := res.Resolve(A{}, conf); got != "domain.A" {
+		t.Fatalf("registered: got %q, want %q", got, "domain.A")
+	}
+	if got := res.Resolve(G[int]{}, conf); got != "strategy_test.G" {
+		t.Fatalf("reflect: got %q, want %q", got, "strategy_test.G")
+	}
+}
+
+// Ensure the local type actually satisfies apis.Describer (compile-time).
+var _ apis.Describer = describedType{}
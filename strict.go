@@ -0,0 +1,60 @@
+/*
+   Copyright 2025 The DIRPX Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rfx
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sync/atomic"
+
+	"dirpx.dev/rfx/apis"
+)
+
+// ErrInconsistentResolution is wrapped by the panic raised in strict
+// consistency mode; see SetStrictConsistency.
+var ErrInconsistentResolution = errors.New("rfx: inconsistent resolution")
+
+// strictConsistency enables the Entity/EntityType cross-check.
+var strictConsistency atomic.Bool
+
+// SetStrictConsistency enables or disables a development check: while
+// enabled, Entity also resolves reflect.TypeOf(v) as EntityType would and
+// panics with an error wrapping ErrInconsistentResolution if the names
+// differ. It is off by default and meant for tests and CI; when disabled,
+// the only overhead on the read path is a single atomic load.
+//
+// Names may legitimately depend on the instance, so values implementing
+// apis.Namer or apis.Identifier are not checked. Custom instance-only
+// strategies (e.g. strategy.NewFuncStrategy) diverge by design as well;
+// leave the check off in chains that use them.
+func SetStrictConsistency(enabled bool) {
+	strictConsistency.Store(enabled)
+}
+
+// checkConsistency panics if v's type resolves to a name other than name
+// in s. It is a no-op for values whose name may depend on the instance.
+func checkConsistency(s *state, v any, name string) {
+	switch v.(type) {
+	case nil, apis.Namer, apis.Identifier:
+		return
+	}
+	t := reflect.TypeOf(v)
+	if byType := s.res.ResolveType(t, s.cfg); byType != name {
+		panic(fmt.Errorf("%w for %v: Entity = %q, EntityType = %q", ErrInconsistentResolution, t, name, byType))
+	}
+}
@@ -0,0 +1,53 @@
+package rfx
+
+import (
+	"errors"
+	"testing"
+
+	"dirpx.dev/rfx/apis"
+	"dirpx.dev/rfx/builder"
+	"dirpx.dev/rfx/config"
+)
+
+// identifiedEntity names itself per instance through apis.Identifier.
+type identifiedEntity struct{ id string }
+
+func (e identifiedEntity) EntityID() string { return e.id }
+
+var _ apis.Identifier = identifiedEntity{}
+
+func TestStrictConsistency_DefaultChainAgrees(t *testing.T) {
+	defer Capture().Restore()
+	defer SetStrictConsistency(false)
+	resetWithBuilder(t, builder.New(), config.DefaultConfig(), nil)
+	SetStrictConsistency(true)
+
+	for _, v := range []any{derivedA{}, &derivedA{}, []derivedA{}, 42, resolveNamed{}, identifiedEntity{id: "x"}, nil} {
+		_ = Entity(v) // must not panic
+	}
+}
+
+func TestStrictConsistency_PanicsOnDivergence(t *testing.T) {
+	defer Capture().Restore()
+	defer SetStrictConsistency(false)
+	resetWithBuilder(t, builder.New(), config.DefaultConfig(), nil)
+	// mockResolver appends the type to ResolveType results only.
+	SetResolver(&mockResolver{id: "mock"})
+
+	// Off by default: divergence goes unnoticed.
+	_ = Entity(derivedA{})
+
+	SetStrictConsistency(true)
+	// Namers and Identifiers may legitimately diverge.
+	_ = Entity(resolveNamed{})
+	_ = Entity(identifiedEntity{id: "x"})
+
+	defer func() {
+		err, _ := recover().(error)
+		if !errors.Is(err, ErrInconsistentResolution) {
+			t.Fatalf("recovered %v, want ErrInconsistentResolution", err)
+		}
+	}()
+	_ = Entity(derivedA{})
+	t.Fatal("Entity should panic on inconsistent resolution")
+}
@@ -0,0 +1,188 @@
+/*
+   Copyright 2025 The DIRPX Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rfx
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+	"sync/atomic"
+
+	"dirpx.dev/rfx/apis"
+)
+
+// TraceEntry records a single resolution made through Entity or EntityType.
+type TraceEntry struct {
+	// Type is the type that was resolved (nil for Entity(nil)).
+	Type reflect.Type
+	// Name is the resolved name.
+	Name string
+	// Source names the strategy that produced Name, as reported by
+	// ResolverStrategyNames. It is "" if no strategy handled the input or the
+	// resolver does not implement apis.SourceResolver.
+	Source string
+}
+
+// tracer is the active trace ring, or nil when tracing is disabled.
+var tracer atomic.Pointer[traceRing]
+
+// EnableTrace starts recording the last n resolutions made through Entity and
+// EntityType into a ring buffer, replacing any previous buffer.
+// n <= 0 disables tracing. When disabled, the only overhead on the read path
+// is a single atomic load.
+//
+// Tracing is a middleware around the global rfx res: each entry records the
+// name the resolver returned, so resolver-level behavior (such as panic
+// recovery in resolver.NewSafe) and EntityCtx's context are unaffected. The
+// source is reported by resolvers implementing apis.SourceResolver.
+func EnableTrace(n int) {
+	if n <= 0 {
+		tracer.Store(nil)
+		return
+	}
+	tracer.Store(&traceRing{buf: make([]TraceEntry, n)})
+}
+
+// TraceDump returns the recorded resolutions, oldest first.
+// It returns nil when tracing is disabled.
+func TraceDump() []TraceEntry {
+	tr := tracer.Load()
+	if tr == nil {
+		return nil
+	}
+	return tr.dump()
+}
+
+// traceRing is a fixed-size, concurrency-safe ring of TraceEntry.
+type traceRing struct {
+	mu   sync.Mutex
+	buf  []TraceEntry
+	next int
+	full bool
+}
+
+// add records e, overwriting the oldest entry when the ring is full.
+func (r *traceRing) add(e TraceEntry) {
+	r.mu.Lock()
+	r.buf[r.next] = e
+	r.next++
+	if r.next == len(r.buf) {
+		r.next = 0
+		r.full = true
+	}
+	r.mu.Unlock()
+}
+
+// dump returns a copy of the ring contents, oldest first.
+func (r *traceRing) dump() []TraceEntry {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.full {
+		return append([]TraceEntry(nil), r.buf[:r.next]...)
+	}
+	out := make([]TraceEntry, 0, len(r.buf))
+	out = append(out, r.buf[r.next:]...)
+	return append(out, r.buf[:r.next]...)
+}
+
+// resolverIn returns the resolver of s, wrapped in the unresolved-tracking
+// and trace middlewares while they are enabled.
+func resolverIn(s *state) apis.Resolver {
+	res := s.res
+	if u := unresolved.Load(); u != nil {
+		res = recording{res: res, u: u}
+	}
+	if tr := tracer.Load(); tr != nil {
+		res = tracing{res: res, tr: tr}
+	}
+	return res
+}
+
+// tracing is the trace middleware: it resolves through res and records
+// every result, with the strategy that produced it, into tr.
+type tracing struct {
+	res apis.Resolver
+	tr  *traceRing
+}
+
+// Resolve resolves v with the wrapped resolver and records the result.
+func (r tracing) Resolve(v any, cfg apis.Config) string {
+	name, src := resolveSource(r.res, v, cfg)
+	r.tr.add(TraceEntry{Type: reflect.TypeOf(v), Name: name, Source: sourceName(r.res, src)})
+	return name
+}
+
+// ResolveType resolves t with the wrapped resolver and records the result.
+func (r tracing) ResolveType(t reflect.Type, cfg apis.Config) string {
+	name, src := resolveTypeSource(r.res, t, cfg)
+	r.tr.add(TraceEntry{Type: t, Name: name, Source: sourceName(r.res, src)})
+	return name
+}
+
+// ResolveCtx resolves v with the wrapped resolver, passing ctx on, and
+// records the result.
+func (r tracing) ResolveCtx(ctx context.Context, v any, cfg apis.Config) string {
+	name, src := resolveCtxSource(r.res, ctx, v, cfg)
+	r.tr.add(TraceEntry{Type: reflect.TypeOf(v), Name: name, Source: sourceName(r.res, src)})
+	return name
+}
+
+// resolveSource resolves v with res, reporting the index of the strategy that
+// produced the name when res is an apis.SourceResolver and -1 otherwise.
+func resolveSource(res apis.Resolver, v any, cfg apis.Config) (string, int) {
+	if sr, ok := res.(apis.SourceResolver); ok {
+		return sr.ResolveSource(v, cfg)
+	}
+	return res.Resolve(v, cfg), -1
+}
+
+// resolveTypeSource is the reflect.Type variant of resolveSource.
+func resolveTypeSource(res apis.Resolver, t reflect.Type, cfg apis.Config) (string, int) {
+	if sr, ok := res.(apis.SourceResolver); ok {
+		return sr.ResolveTypeSource(t, cfg)
+	}
+	return res.ResolveType(t, cfg), -1
+}
+
+// resolveCtxSource is the context variant of resolveSource. Resolvers that
+// are not apis.ContextResolver resolve v without ctx.
+func resolveCtxSource(res apis.Resolver, ctx context.Context, v any, cfg apis.Config) (string, int) {
+	switch r := res.(type) {
+	case apis.SourceResolver:
+		return r.ResolveCtxSource(ctx, v, cfg)
+	case apis.ContextResolver:
+		return r.ResolveCtx(ctx, v, cfg), -1
+	}
+	return res.Resolve(v, cfg), -1
+}
+
+// sourceName returns the name of strategy i of res, or "" if i < 0.
+func sourceName(res apis.Resolver, i int) string {
+	if i < 0 {
+		return ""
+	}
+	return strategyName(res.(apis.StrategyLister).Strategies()[i])
+}
+
+// strategyName returns the reported name of s, or its concrete type name.
+func strategyName(s apis.Strategy) string {
+	if n, ok := s.(apis.NamedStrategy); ok {
+		return n.Name()
+	}
+	return fmt.Sprintf("%T", s)
+}
@@ -0,0 +1,118 @@
+package rfx
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"dirpx.dev/rfx/apis"
+	"dirpx.dev/rfx/builder"
+	"dirpx.dev/rfx/config"
+	"dirpx.dev/rfx/resolver"
+	"dirpx.dev/rfx/strategy"
+)
+
+type tracedType struct{}
+
+func TestTrace_RecordsInOrder(t *testing.T) {
+	resetWithBuilder(t, builder.New(), config.DefaultConfig(), nil)
+	Registry().Reset()
+	EnableTrace(3)
+	defer EnableTrace(0)
+
+	if err := RegisterType(reflect.TypeOf(derivedB{}), "traced.b"); err != nil {
+		t.Fatalf("RegisterType: %v", err)
+	}
+
+	Entity(nilOrder{})                     // dropped: ring holds 3
+	Entity(nilOrder{})                     // namer
+	EntityType(reflect.TypeOf(derivedB{})) // registry
+	Entity(tracedType{})                   // reflect
+
+	got := TraceDump()
+	want := []TraceEntry{
+		{Type: reflect.TypeOf(nilOrder{}), Name: "nil.order", Source: "namer"},
+		{Type: reflect.TypeOf(derivedB{}), Name: "traced.b", Source: "registry"},
+		{Type: reflect.TypeOf(tracedType{}), Name: "rfx.tracedType", Source: "reflect"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("TraceDump() = %+v, want %+v", got, want)
+	}
+}
+
+func TestTrace_Disabled(t *testing.T) {
+	EnableTrace(0)
+	Entity(tracedType{})
+	if got := TraceDump(); got != nil {
+		t.Fatalf("TraceDump() = %v, want nil", got)
+	}
+
+	EnableTrace(4)
+	defer EnableTrace(0)
+	if got := TraceDump(); len(got) != 0 {
+		t.Fatalf("fresh trace should be empty, got %v", got)
+	}
+}
+
+func BenchmarkEntity_TraceEnabled(b *testing.B) {
+	EnableTrace(1024)
+	defer EnableTrace(0)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = Entity(tracedType{})
+	}
+}
+
+func TestTrace_HonoursSkipEmpty(t *testing.T) {
+	defer Capture().Restore()
+	resetWithBuilder(t, builder.New(), config.NewConfig(config.WithIncludeBuiltins(false)), nil)
+	// The reflect strategy handles int with "" (builtins hidden); the
+	// skip-empty chain must fall through to the static map.
+	SetResolver(resolver.NewSkipEmpty(
+		strategy.NewReflectStrategy(),
+		strategy.NewStaticMapStrategy(map[reflect.Type]string{reflect.TypeOf(0): "builtin.int"}),
+	))
+
+	untraced := Entity(0)
+	EnableTrace(1)
+	defer EnableTrace(0)
+	traced := Entity(0)
+	if untraced != "builtin.int" || traced != untraced {
+		t.Fatalf("untraced = %q, traced = %q, want both builtin.int", untraced, traced)
+	}
+	if got := TraceDump(); len(got) != 1 || got[0].Source != "static-map" {
+		t.Fatalf("TraceDump() = %+v, want source static-map", got)
+	}
+	want := `int -> named int -> reflect miss -> static-map: "builtin.int"`
+	if got := Explain(0); got != want {
+		t.Fatalf("Explain:\n got %s\nwant %s", got, want)
+	}
+}
+
+// tracePanicStrategy panics on every call.
+type tracePanicStrategy struct{}
+
+func (tracePanicStrategy) TryResolve(any, apis.Config) (string, bool) { panic("boom") }
+func (tracePanicStrategy) TryResolveType(reflect.Type, apis.Config) (string, bool) {
+	panic("boom")
+}
+
+func TestTrace_UsesGlobalResolver(t *testing.T) {
+	defer Capture().Restore()
+	resetWithBuilder(t, builder.New(), config.DefaultConfig(), nil)
+	SetResolver(resolver.NewSafe(tracePanicStrategy{}, strategy.NewReflectStrategy()))
+
+	EnableTrace(4)
+	defer EnableTrace(0)
+	// NewSafe recovers the panic; tracing must not bypass it.
+	if got := Entity(tracedType{}); got != "rfx.tracedType" {
+		t.Fatalf("Entity = %q, want rfx.tracedType", got)
+	}
+	if got := EntityCtx(context.Background(), tracedType{}); got != "rfx.tracedType" {
+		t.Fatalf("EntityCtx = %q, want rfx.tracedType", got)
+	}
+	got := TraceDump()
+	if len(got) != 2 || got[0].Source != "reflect" || got[1].Source != "reflect" {
+		t.Fatalf("TraceDump() = %+v, want two reflect entries", got)
+	}
+}
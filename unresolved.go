@@ -0,0 +1,172 @@
+/*
+   Copyright 2025 The DIRPX Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rfx
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"sync"
+	"sync/atomic"
+
+	"dirpx.dev/rfx/apis"
+	"dirpx.dev/rfx/strategy"
+	uref "dirpx.dev/rfx/utils/reflect"
+)
+
+// MaxUnresolvedTypes bounds the number of distinct types recorded while
+// TrackUnresolved is enabled. Further unresolved types are dropped.
+const MaxUnresolvedTypes = 1024
+
+// unresolved is the active set of unresolved types, or nil when tracking is disabled.
+var unresolved atomic.Pointer[unresolvedSet]
+
+// TrackUnresolved enables or disables recording of types that Entity and
+// EntityType resolve to "" or only via the reflect fallback, to help discover
+// types that need explicit names. Enabling while already enabled keeps the
+// recorded types; disabling drops them. At most MaxUnresolvedTypes distinct
+// types are kept. When disabled, the only overhead on the read path is a
+// single atomic load.
+//
+// Like tracing, tracking is a middleware around the global rfx res. A result
+// counts as a reflect fallback when the resolver reports, through
+// apis.SourceResolver, that the stock reflect strategy produced it.
+func TrackUnresolved(enabled bool) {
+	if !enabled {
+		unresolved.Store(nil)
+		return
+	}
+	unresolved.CompareAndSwap(nil, &unresolvedSet{})
+}
+
+// UnresolvedTypes returns the types recorded since TrackUnresolved(true),
+// sorted by full type name. It returns nil when tracking is disabled.
+func UnresolvedTypes() []reflect.Type {
+	u := unresolved.Load()
+	if u == nil {
+		return nil
+	}
+	return u.types()
+}
+
+// recordUnresolved adds t to the active unresolved set, if any, for paths
+// that resolve without the global rfx res.
+func recordUnresolved(t reflect.Type) {
+	if u := unresolved.Load(); u != nil && t != nil {
+		u.add(t)
+	}
+}
+
+// recording is the unresolved-tracking middleware: it resolves through res
+// and records the types that resolve to "" or only via the reflect fallback.
+type recording struct {
+	res apis.Resolver
+	u   *unresolvedSet
+}
+
+// Resolve resolves v with the wrapped resolver, recording its type if needed.
+func (r recording) Resolve(v any, cfg apis.Config) string {
+	name, _ := r.ResolveSource(v, cfg)
+	return name
+}
+
+// ResolveType resolves t with the wrapped resolver, recording it if needed.
+func (r recording) ResolveType(t reflect.Type, cfg apis.Config) string {
+	name, _ := r.ResolveTypeSource(t, cfg)
+	return name
+}
+
+// ResolveCtx resolves v with the wrapped resolver, passing ctx on, and
+// records its type if needed.
+func (r recording) ResolveCtx(ctx context.Context, v any, cfg apis.Config) string {
+	name, _ := r.ResolveCtxSource(ctx, v, cfg)
+	return name
+}
+
+// Strategies returns the strategies of the wrapped resolver, if it lists any.
+func (r recording) Strategies() []apis.Strategy {
+	if l, ok := r.res.(apis.StrategyLister); ok {
+		return l.Strategies()
+	}
+	return nil
+}
+
+// ResolveSource resolves v like Resolve and passes the source on.
+func (r recording) ResolveSource(v any, cfg apis.Config) (string, int) {
+	name, src := resolveSource(r.res, v, cfg)
+	r.record(reflect.TypeOf(v), name, src)
+	return name, src
+}
+
+// ResolveTypeSource resolves t like ResolveType and passes the source on.
+func (r recording) ResolveTypeSource(t reflect.Type, cfg apis.Config) (string, int) {
+	name, src := resolveTypeSource(r.res, t, cfg)
+	r.record(t, name, src)
+	return name, src
+}
+
+// ResolveCtxSource resolves v like ResolveCtx and passes the source on.
+func (r recording) ResolveCtxSource(ctx context.Context, v any, cfg apis.Config) (string, int) {
+	name, src := resolveCtxSource(r.res, ctx, v, cfg)
+	r.record(reflect.TypeOf(v), name, src)
+	return name, src
+}
+
+// record adds t to the set if name is empty or was produced by the reflect
+// strategy at index src.
+func (r recording) record(t reflect.Type, name string, src int) {
+	if t == nil {
+		return
+	}
+	if name == "" || (src >= 0 && r.Strategies()[src] == strategy.NewReflectStrategy()) {
+		r.u.add(t)
+	}
+}
+
+// unresolvedSet is a bounded, concurrency-safe set of types.
+type unresolvedSet struct {
+	mu sync.Mutex
+	m  sync.Map // map[reflect.Type]struct{}
+	n  int
+}
+
+// add records t unless it is already present or the set is full.
+func (u *unresolvedSet) add(t reflect.Type) {
+	// Fast path: repeated misses for the same type take no lock.
+	if _, ok := u.m.Load(t); ok {
+		return
+	}
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if u.n >= MaxUnresolvedTypes {
+		return
+	}
+	if _, loaded := u.m.LoadOrStore(t, struct{}{}); !loaded {
+		u.n++
+	}
+}
+
+// types returns the recorded types sorted by full type name.
+func (u *unresolvedSet) types() []reflect.Type {
+	var out []reflect.Type
+	u.m.Range(func(k, _ any) bool {
+		out = append(out, k.(reflect.Type))
+		return true
+	})
+	sort.Slice(out, func(i, j int) bool { return uref.FullName(out[i]) < uref.FullName(out[j]) })
+	return out
+}
@@ -0,0 +1,77 @@
+package rfx
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"dirpx.dev/rfx/builder"
+	"dirpx.dev/rfx/config"
+)
+
+func TestTrackUnresolved(t *testing.T) {
+	defer Capture().Restore()
+	resetWithBuilder(t, builder.New(), config.DefaultConfig(), nil)
+	Registry().Reset()
+	SetReflectFallback(false)
+	_ = RegisterType(reflect.TypeOf(derivedA{}), "tracked.a")
+
+	Entity(derivedB{}) // not recorded: tracking is off
+	if got := UnresolvedTypes(); got != nil {
+		t.Fatalf("UnresolvedTypes() while disabled = %v, want nil", got)
+	}
+
+	TrackUnresolved(true)
+	defer TrackUnresolved(false)
+
+	Entity(derivedA{})                     // registered
+	Entity(nilOrder{})                     // namer
+	Entity(unregisteredType{})             // unresolved
+	Entity(unregisteredType{})             // recorded once
+	EntityType(reflect.TypeOf(derivedB{})) // unresolved
+	Entity(nil)                            // no type to record
+	TrackUnresolved(true)                  // keeps the set
+
+	got := UnresolvedTypes()
+	want := []reflect.Type{reflect.TypeOf(derivedB{}), reflect.TypeOf(unregisteredType{})}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("UnresolvedTypes() = %v, want %v", got, want)
+	}
+}
+
+func TestTrackUnresolved_Bounded(t *testing.T) {
+	defer Capture().Restore()
+	resetWithBuilder(t, builder.New(), config.DefaultConfig(), nil)
+	SetReflectFallback(false)
+	TrackUnresolved(true)
+	defer TrackUnresolved(false)
+
+	elem := reflect.TypeOf(unregisteredType{})
+	for i := 0; i < MaxUnresolvedTypes+10; i++ {
+		EntityType(reflect.ArrayOf(i, elem))
+	}
+	if got := len(UnresolvedTypes()); got != MaxUnresolvedTypes {
+		t.Fatalf("len(UnresolvedTypes()) = %d, want %d", got, MaxUnresolvedTypes)
+	}
+}
+
+func TestTrackUnresolved_ReflectFallback(t *testing.T) {
+	defer Capture().Restore()
+	resetWithBuilder(t, builder.New(), config.DefaultConfig(), nil)
+	Registry().Reset()
+	_ = RegisterType(reflect.TypeOf(derivedA{}), "tracked.a")
+	TrackUnresolved(true)
+	defer TrackUnresolved(false)
+
+	Entity(derivedA{})                                  // registered
+	Entity(nilOrder{})                                  // namer
+	EntityCtx(context.Background(), derivedB{})         // reflect
+	EntityTypeExact(reflect.TypeOf(derivedA{}))         // registered
+	EntityTypeExact(reflect.TypeOf(unregisteredType{})) // reflect
+
+	got := UnresolvedTypes()
+	want := []reflect.Type{reflect.TypeOf(derivedB{}), reflect.TypeOf(unregisteredType{})}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("UnresolvedTypes() = %v, want %v", got, want)
+	}
+}
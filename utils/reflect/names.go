@@ -0,0 +1,88 @@
+/*
+   Copyright 2025 The DIRPX Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package reflect
+
+import (
+	"path"
+	"reflect"
+	"strings"
+)
+
+// FullName returns a stable, unabbreviated string for t:
+// "pkgpath.Name" for named types declared in a package, the plain name for
+// builtin named types (e.g. "int"), and t.String() for unnamed types.
+// A nil type yields "".
+func FullName(t reflect.Type) string {
+	if t == nil {
+		return ""
+	}
+	if t.Name() == "" {
+		return t.String()
+	}
+	if p := t.PkgPath(); p != "" {
+		return p + "." + t.Name()
+	}
+	return t.Name()
+}
+
+// PackageBase returns the last element of pkgPath, as path.Base does. With
+// trimModuleVersion, a trailing major version segment ("/v2", "/v3", ...) is
+// dropped first, so "github.com/a/b/v2" yields "b" rather than "v2". A path
+// that consists of the version segment alone is returned unchanged.
+func PackageBase(pkgPath string, trimModuleVersion bool) string {
+	if trimModuleVersion {
+		if i := strings.LastIndexByte(pkgPath, '/'); i > 0 && isMajorVersion(pkgPath[i+1:]) {
+			pkgPath = pkgPath[:i]
+		}
+	}
+	return path.Base(pkgPath)
+}
+
+// isMajorVersion reports whether s is a module major version suffix "vN", N >= 2.
+func isMajorVersion(s string) bool {
+	if len(s) < 2 || s[0] != 'v' || s[1] == '0' || s == "v1" {
+		return false
+	}
+	for i := 1; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// StripTypeParams removes a generic instantiation suffix: "T[int,string]" -> "T".
+func StripTypeParams(s string) string {
+	if i := strings.IndexByte(s, '['); i >= 0 {
+		return s[:i]
+	}
+	return s
+}
+
+// GenericBaseName returns "pkgpath.Name" of the generic type definition that t
+// instantiates (e.g. "example.com/pkg.G" for G[int]), or "" if t is not an
+// instantiated generic type.
+func GenericBaseName(t reflect.Type) string {
+	if t == nil {
+		return ""
+	}
+	n := t.Name()
+	if strings.IndexByte(n, '[') < 0 {
+		return ""
+	}
+	return t.PkgPath() + "." + StripTypeParams(n)
+}
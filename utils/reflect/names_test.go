@@ -0,0 +1,94 @@
+/*
+   Copyright 2025 The DIRPX Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package reflect_test
+
+import (
+	"reflect"
+	"testing"
+
+	uref "dirpx.dev/rfx/utils/reflect"
+)
+
+func TestFullName(t *testing.T) {
+	cases := []struct {
+		name string
+		typ  reflect.Type
+		want string
+	}{
+		{"nil", nil, ""},
+		{"named", reflect.TypeOf(A{}), "dirpx.dev/rfx/utils/reflect_test.A"},
+		{"builtin", reflect.TypeOf(0), "int"},
+		{"unnamed", reflect.TypeOf([]A{}), "[]reflect_test.A"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := uref.FullName(tc.typ); got != tc.want {
+				t.Fatalf("FullName(%v) = %q, want %q", tc.typ, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestPackageBase(t *testing.T) {
+	cases := []struct {
+		path string
+		trim bool
+		want string
+	}{
+		{"github.com/a/b", true, "b"},
+		{"github.com/a/b/v2", true, "b"},
+		{"github.com/a/b/v12", true, "b"},
+		{"github.com/a/b/v2", false, "v2"},
+		{"github.com/a/b/v1", true, "v1"},
+		{"github.com/a/b/v0", true, "v0"},
+		{"github.com/a/b/vx", true, "vx"},
+		{"v2", true, "v2"},
+		{"time", true, "time"},
+	}
+	for _, c := range cases {
+		if got := uref.PackageBase(c.path, c.trim); got != c.want {
+			t.Errorf("PackageBase(%q, %v) = %q, want %q", c.path, c.trim, got, c.want)
+		}
+	}
+}
+
+func TestStripTypeParams(t *testing.T) {
+	for in, want := range map[string]string{
+		"T":              "T",
+		"T[int]":         "T",
+		"T[int,string]":  "T",
+		"W[map[int]int]": "W",
+		"":               "",
+	} {
+		if got := uref.StripTypeParams(in); got != want {
+			t.Errorf("StripTypeParams(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestGenericBaseName(t *testing.T) {
+	const pkg = "dirpx.dev/rfx/utils/reflect_test"
+	if got := uref.GenericBaseName(reflect.TypeOf(G[int]{})); got != pkg+".G" {
+		t.Fatalf("G[int]: got %q, want %q", got, pkg+".G")
+	}
+	if got := uref.GenericBaseName(reflect.TypeOf(A{})); got != "" {
+		t.Fatalf("non-generic: got %q, want empty", got)
+	}
+	if got := uref.GenericBaseName(nil); got != "" {
+		t.Fatalf("nil: got %q, want empty", got)
+	}
+}
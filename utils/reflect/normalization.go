@@ -30,6 +30,9 @@ var (
 	// ErrReflectTypeNotNamed indicates that the provided type (after unwrapping containers)
 	// does not contain a named type (e.g., anonymous struct, func, interface{}).
 	ErrReflectTypeNotNamed = errors.New("reflect: type has no registered name")
+	// ErrReflectUnsafeKind is returned when normalization lands on uintptr or
+	// unsafe.Pointer and cfg.UnsafeKinds is apis.UnsafeKindError.
+	ErrReflectUnsafeKind = errors.New("reflect: uintptr or unsafe.Pointer type")
 )
 
 // Normalize unwraps containers according to config (MaxUnwrap/MapPreferElem)
@@ -41,53 +44,105 @@ var (
 //     if the preferred side is named, return it;
 //     else try the other side; if still unnamed, continue unwrapping Elem().
 //   - default: if t.Name() != "", return t; otherwise ErrNotNamed.
+//   - uintptr/unsafe.Pointer: treated as unnamed under apis.UnsafeKindReject;
+//     under apis.UnsafeKindError, ErrReflectUnsafeKind is returned if
+//     normalization lands on one of them (see Config.UnsafeKindPolicy).
 //
-// If MaxUnwrap <= 0, DefaultMaxUnwrap is used.
+// With NormalizeOutermost, any named type reached while unwrapping is returned
+// as is, even if it is itself a container: OrderList ([]Order) and *OrderList
+// yield OrderList, while the unnamed []Order still yields Order. Map sides are
+// checked in the same preferred order, so a named container on either side is
+// returned whole.
+//
+// MaxUnwrap = 0 unwraps nothing, so only exact named types resolve; a
+// negative MaxUnwrap uses DefaultMaxUnwrap. With UnwrapFinalNamed, a pointer
+// or slice left over when MaxUnwrap is exhausted is unwrapped once more if
+// its element is named, so **A normalizes to A at MaxUnwrap = 1 (and *A at
+// MaxUnwrap = 0).
 func Normalize(t reflect.Type, cfg apis.Config) (reflect.Type, error) {
+	return normalize(t, cfg, nil)
+}
+
+// NormalizeDetailed behaves like Normalize and additionally returns the
+// container layers that were unwrapped to reach the named type, outermost
+// first. For []*User it returns User and the layers [[]*User, *User].
+// On error the returned layers are nil.
+func NormalizeDetailed(t reflect.Type, cfg apis.Config) (reflect.Type, []reflect.Type, error) {
+	var layers []reflect.Type
+	base, err := normalize(t, cfg, &layers)
+	if err != nil {
+		return nil, nil, err
+	}
+	return base, layers, nil
+}
+
+// normalize implements Normalize, recording each unwrapped container into
+// layers when it is non-nil.
+func normalize(t reflect.Type, cfg apis.Config, layers *[]reflect.Type) (reflect.Type, error) {
+	base, err := unwrap(t, cfg, layers)
+	if err == nil && cfg.UnsafeKindPolicy() == apis.UnsafeKindError && isUnsafeKind(base.Kind()) {
+		return nil, ErrReflectUnsafeKind
+	}
+	return base, err
+}
+
+// unwrap walks t down to the nearest named type as described on Normalize.
+func unwrap(t reflect.Type, cfg apis.Config, layers *[]reflect.Type) (reflect.Type, error) {
 	if t == nil {
 		return nil, ErrReflectNilType
 	}
 	maxUnwrap := cfg.MaxUnwrap
-	if maxUnwrap <= 0 {
+	if maxUnwrap < 0 {
 		maxUnwrap = config.DefaultMaxUnwrap
 	}
 
 	preferElem := cfg.MapPreferElem
+	record := func(c reflect.Type) {
+		if layers != nil {
+			*layers = append(*layers, c)
+		}
+	}
 
 	for i := 0; t != nil && i < maxUnwrap; i++ {
+		// Outermost mode: stop at the first named type, container or not.
+		if cfg.NormalizeOutermost && isNamed(t, cfg) {
+			return t, nil
+		}
 		switch t.Kind() {
 		case reflect.Ptr, reflect.Slice, reflect.Array, reflect.Chan:
+			record(t)
 			t = t.Elem()
 
 		case reflect.Map:
+			record(t)
 			// Try preferred side
 			if preferElem {
 				et := t.Elem()
-				if et != nil && et.Name() != "" {
+				if isNamed(et, cfg) {
 					return et, nil
 				}
 				// Fallback to the other side
 				kt := t.Key()
-				if kt != nil && kt.Name() != "" {
+				if isNamed(kt, cfg) {
 					return kt, nil
 				}
 				// Neither side named: keep unwrapping element
 				t = et
 			} else {
 				kt := t.Key()
-				if kt != nil && kt.Name() != "" {
+				if isNamed(kt, cfg) {
 					return kt, nil
 				}
 				et := t.Elem()
-				if et != nil && et.Name() != "" {
+				if isNamed(et, cfg) {
 					return et, nil
 				}
 				t = et
 			}
 
 		default:
-			// Named, return; anonymous -> error
-			if t.Name() != "" {
+			// Named, return; anonymous (or rejected unsafe kind) -> error
+			if isNamed(t, cfg) {
 				return t, nil
 			}
 			return nil, ErrReflectTypeNotNamed
@@ -95,8 +150,42 @@ func Normalize(t reflect.Type, cfg apis.Config) (reflect.Type, error) {
 	}
 
 	// After reaching max depth, ensure we ended on a named type.
-	if t != nil && t.Name() != "" {
+	if isNamed(t, cfg) {
 		return t, nil
 	}
+	// Optionally peel one final pointer or slice layer onto a named element.
+	if cfg.UnwrapFinalNamed && t != nil && (t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice) && isNamed(t.Elem(), cfg) {
+		record(t)
+		return t.Elem(), nil
+	}
 	return nil, ErrReflectTypeNotNamed
 }
+
+// isNamed reports whether t is a named type acceptable as a normalization result.
+// uintptr and unsafe.Pointer count as unnamed under apis.UnsafeKindReject.
+func isNamed(t reflect.Type, cfg apis.Config) bool {
+	if t == nil || t.Name() == "" {
+		return false
+	}
+	return !isUnsafeKind(t.Kind()) || cfg.UnsafeKindPolicy() != apis.UnsafeKindReject
+}
+
+// IsUnsafeKind reports whether k is uintptr or unsafe.Pointer.
+func IsUnsafeKind(k reflect.Kind) bool {
+	return isUnsafeKind(k)
+}
+
+// IsContainerKind reports whether Normalize unwraps values of kind k:
+// pointer, slice, array, chan or map.
+func IsContainerKind(k reflect.Kind) bool {
+	switch k {
+	case reflect.Ptr, reflect.Slice, reflect.Array, reflect.Chan, reflect.Map:
+		return true
+	}
+	return false
+}
+
+// isUnsafeKind reports whether k is uintptr or unsafe.Pointer.
+func isUnsafeKind(k reflect.Kind) bool {
+	return k == reflect.Uintptr || k == reflect.UnsafePointer
+}
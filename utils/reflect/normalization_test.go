@@ -22,6 +22,7 @@ import (
 	"runtime"
 	"sync"
 	"testing"
+	"unsafe"
 
 	"dirpx.dev/rfx/apis"
 	uref "dirpx.dev/rfx/utils/reflect"
@@ -118,6 +119,35 @@ func TestNormalize_GenericInstantiation(t *testing.T) {
 	}
 }
 
+func TestNormalize_UnwrapFinalNamed(t *testing.T) {
+	one := cfg(func(c *apis.Config) { c.MaxUnwrap = 1 })
+	final := cfg(func(c *apis.Config) { c.MaxUnwrap = 1; c.UnwrapFinalNamed = true })
+	ppA := reflect.TypeOf((**A)(nil))
+
+	if _, err := uref.Normalize(ppA, one); !errors.Is(err, uref.ErrReflectTypeNotNamed) {
+		t.Fatalf("**A at MaxUnwrap=1: want ErrReflectTypeNotNamed, got %v", err)
+	}
+	if got, err := uref.Normalize(ppA, final); err != nil || got != reflect.TypeOf(A{}) {
+		t.Fatalf("**A at MaxUnwrap=1 with UnwrapFinalNamed: got (%v,%v), want (A,nil)", got, err)
+	}
+	_, layers, err := uref.NormalizeDetailed(ppA, final)
+	if err != nil || len(layers) != 2 {
+		t.Fatalf("NormalizeDetailed(**A): got %d layers, err %v; want 2, nil", len(layers), err)
+	}
+	if got, err := uref.Normalize(reflect.TypeOf([][]A{}), final); err != nil || got != reflect.TypeOf(A{}) {
+		t.Fatalf("[][]A with UnwrapFinalNamed: got (%v,%v), want (A,nil)", got, err)
+	}
+
+	// Only one extra layer is granted.
+	if _, err := uref.Normalize(reflect.TypeOf((***A)(nil)), final); !errors.Is(err, uref.ErrReflectTypeNotNamed) {
+		t.Fatalf("***A at MaxUnwrap=1: want ErrReflectTypeNotNamed, got %v", err)
+	}
+	// Only pointers and slices qualify.
+	if _, err := uref.Normalize(reflect.TypeOf((*map[string]A)(nil)), final); !errors.Is(err, uref.ErrReflectTypeNotNamed) {
+		t.Fatalf("*map[string]A at MaxUnwrap=1: want ErrReflectTypeNotNamed, got %v", err)
+	}
+}
+
 func TestNormalize_MaxUnwrap(t *testing.T) {
 	// **A with low MaxUnwrap should fail, with larger MaxUnwrap should succeed.
 	type PP = **A
@@ -258,7 +288,7 @@ func BenchmarkNormalize_VariousConfigs(b *testing.B) {
 
 // runName builds a compact sub-benchmark name like "M-E-U8-B+" safely.
 func runName(c apis.Config) string {
-	// Map side: E/K; Builtins: +/-; Unwrap: U<number> (default to 8 if <= 0).
+	// Map side: E/K; Builtins: +/-; Unwrap: U<number> (default to 8 if < 0).
 	m := byte('E')
 	if !c.MapPreferElem {
 		m = 'K'
@@ -268,7 +298,7 @@ func runName(c apis.Config) string {
 		b = '-'
 	}
 	u := c.MaxUnwrap
-	if u <= 0 {
+	if u < 0 {
 		u = 8
 	}
 
@@ -312,3 +342,108 @@ func runName(c apis.Config) string {
 
 	return string(buf[:i])
 }
+
+func TestNormalize_UnsafeKindError(t *testing.T) {
+	failing := cfg(func(c *apis.Config) { c.UnsafeKinds = apis.UnsafeKindError })
+
+	for _, typ := range []reflect.Type{
+		reflect.TypeOf(uintptr(0)),
+		reflect.TypeOf(unsafe.Pointer(nil)),
+		reflect.TypeOf([]*uintptr{}),
+	} {
+		if _, err := uref.Normalize(typ, failing); !errors.Is(err, uref.ErrReflectUnsafeKind) {
+			t.Errorf("Normalize(%v): want ErrReflectUnsafeKind, got %v", typ, err)
+		}
+	}
+	if got, err := uref.Normalize(reflect.TypeOf(map[uintptr]A{}), failing); err != nil || got != reflect.TypeOf(A{}) {
+		t.Fatalf("map[uintptr]A: got (%v,%v), want (A,nil)", got, err)
+	}
+}
+
+func TestNormalize_UnsafeKindReject(t *testing.T) {
+	reject := cfg(func(c *apis.Config) { c.UnsafeKinds = apis.UnsafeKindReject })
+
+	cases := []struct {
+		name string
+		typ  reflect.Type
+	}{
+		{"uintptr", reflect.TypeOf(uintptr(0))},
+		{"unsafe.Pointer", reflect.TypeOf(unsafe.Pointer(nil))},
+		{"ptr to uintptr", reflect.TypeOf(new(uintptr))},
+		{"slice of unsafe.Pointer", reflect.TypeOf([]unsafe.Pointer{})},
+		{"map with uintptr key", reflect.TypeOf(map[uintptr]unsafe.Pointer{})},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := uref.Normalize(tc.typ, cfg()); err != nil {
+				t.Fatalf("default config: unexpected error: %v", err)
+			}
+			if _, err := uref.Normalize(tc.typ, reject); !errors.Is(err, uref.ErrReflectTypeNotNamed) {
+				t.Fatalf("UnsafeKindReject: want ErrReflectTypeNotNamed, got %v", err)
+			}
+			legacy := cfg(func(c *apis.Config) { c.RejectUnsafeKinds = true })
+			if _, err := uref.Normalize(tc.typ, legacy); !errors.Is(err, uref.ErrReflectTypeNotNamed) {
+				t.Fatalf("RejectUnsafeKinds: want ErrReflectTypeNotNamed, got %v", err)
+			}
+		})
+	}
+
+	// A map with a safe side still resolves to that side.
+	got, err := uref.Normalize(reflect.TypeOf(map[uintptr]A{}), reject)
+	if err != nil || got != reflect.TypeOf(A{}) {
+		t.Fatalf("map[uintptr]A: got (%v,%v), want (A,nil)", got, err)
+	}
+}
+
+func TestNormalizeDetailed_Layers(t *testing.T) {
+	typ := reflect.TypeOf([]*A{})
+	base, layers, err := uref.NormalizeDetailed(typ, cfg())
+	if err != nil || base != reflect.TypeOf(A{}) {
+		t.Fatalf("got (%v,%v), want (A,nil)", base, err)
+	}
+	want := []reflect.Type{typ, typ.Elem()}
+	if !reflect.DeepEqual(layers, want) {
+		t.Fatalf("layers = %v, want %v", layers, want)
+	}
+
+	if _, layers, _ := uref.NormalizeDetailed(reflect.TypeOf(A{}), cfg()); len(layers) != 0 {
+		t.Fatalf("named type: want no layers, got %v", layers)
+	}
+	if _, layers, err := uref.NormalizeDetailed(reflect.TypeOf([]struct{}{}), cfg()); err == nil || layers != nil {
+		t.Fatalf("unnamed: want (nil layers, error), got (%v,%v)", layers, err)
+	}
+}
+
+type OrderList []A
+type OrderPtr *A
+type OrderChan chan A
+type OrderFunc func(A)
+
+func TestNormalize_Outermost(t *testing.T) {
+	outer := cfg(func(c *apis.Config) { c.NormalizeOutermost = true })
+
+	cases := []struct {
+		name       string
+		typ        reflect.Type
+		inner, out reflect.Type
+	}{
+		{"named slice", reflect.TypeOf(OrderList{}), reflect.TypeOf(A{}), reflect.TypeOf(OrderList{})},
+		{"ptr to named slice", reflect.TypeOf(&OrderList{}), reflect.TypeOf(A{}), reflect.TypeOf(OrderList{})},
+		{"named pointer", reflect.TypeOf(OrderPtr(nil)), reflect.TypeOf(A{}), reflect.TypeOf(OrderPtr(nil))},
+		{"named chan", reflect.TypeOf(OrderChan(nil)), reflect.TypeOf(A{}), reflect.TypeOf(OrderChan(nil))},
+		{"named func", reflect.TypeOf(OrderFunc(nil)), reflect.TypeOf(OrderFunc(nil)), reflect.TypeOf(OrderFunc(nil))},
+		{"slice of named pointer", reflect.TypeOf([]OrderPtr{}), reflect.TypeOf(A{}), reflect.TypeOf(OrderPtr(nil))},
+		{"unnamed slice", reflect.TypeOf([]A{}), reflect.TypeOf(A{}), reflect.TypeOf(A{})},
+		{"map of named slice", reflect.TypeOf(map[string]OrderList{}), reflect.TypeOf(OrderList{}), reflect.TypeOf(OrderList{})},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got, err := uref.Normalize(tc.typ, cfg()); err != nil || got != tc.inner {
+				t.Fatalf("innermost: got (%v,%v), want %v", got, err, tc.inner)
+			}
+			if got, err := uref.Normalize(tc.typ, outer); err != nil || got != tc.out {
+				t.Fatalf("outermost: got (%v,%v), want %v", got, err, tc.out)
+			}
+		})
+	}
+}
@@ -0,0 +1,40 @@
+/*
+   Copyright 2025 The DIRPX Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rfx
+
+import (
+	"reflect"
+	"sort"
+
+	uref "dirpx.dev/rfx/utils/reflect"
+)
+
+// WarmRegistry resolves every type in the global rfx reg through the global
+// rfx res, populating any caches along the way, and returns the types that
+// resolved to an empty name (likely misregistrations), sorted by full type
+// name. An empty result means every registered type has a name.
+func WarmRegistry() []reflect.Type {
+	s := st.Load()
+	var empty []reflect.Type
+	for _, e := range s.reg.Entries() {
+		if s.res.ResolveType(e.Type, s.cfg) == "" {
+			empty = append(empty, e.Type)
+		}
+	}
+	sort.Slice(empty, func(i, j int) bool { return uref.FullName(empty[i]) < uref.FullName(empty[j]) })
+	return empty
+}
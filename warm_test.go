@@ -0,0 +1,39 @@
+package rfx
+
+import (
+	"reflect"
+	"testing"
+
+	"dirpx.dev/rfx/builder"
+	"dirpx.dev/rfx/config"
+	"dirpx.dev/rfx/resolver"
+)
+
+func TestWarmRegistry_AllResolve(t *testing.T) {
+	defer Capture().Restore()
+	resetWithBuilder(t, builder.New(), config.DefaultConfig(), nil)
+	Registry().Reset()
+	if err := RegisterType(reflect.TypeOf(derivedA{}), "warm.a"); err != nil {
+		t.Fatalf("RegisterType: %v", err)
+	}
+
+	if got := WarmRegistry(); len(got) != 0 {
+		t.Fatalf("WarmRegistry() = %v, want empty", got)
+	}
+}
+
+func TestWarmRegistry_NullResolverReportsAll(t *testing.T) {
+	defer Capture().Restore()
+	resetWithBuilder(t, builder.New(), config.DefaultConfig(), nil)
+	Registry().Reset()
+	_ = RegisterType(reflect.TypeOf(derivedB{}), "warm.b")
+	_ = RegisterType(reflect.TypeOf(derivedA{}), "warm.a")
+
+	SetResolver(resolver.New()) // no strategies: everything resolves to ""
+
+	got := WarmRegistry()
+	want := []reflect.Type{reflect.TypeOf(derivedA{}), reflect.TypeOf(derivedB{})}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("WarmRegistry() = %v, want %v", got, want)
+	}
+}